@@ -0,0 +1,7 @@
+data "dataminded_chapter" "platform" {
+  name = "platform"
+}
+
+output "platform_leads" {
+  value = data.dataminded_chapter.platform.leads[*].name
+}
@@ -0,0 +1,8 @@
+data "dataminded_chapters" "all" {}
+
+output "chapter_sizes" {
+  value = {
+    for chapter in data.dataminded_chapters.all.chapters :
+    chapter.name => length(chapter.leads) + length(chapter.contributors)
+  }
+}
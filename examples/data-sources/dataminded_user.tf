@@ -0,0 +1,7 @@
+data "dataminded_user" "jelle" {
+  name = "Jelle"
+}
+
+output "jelle_chapters" {
+  value = { for chapter in data.dataminded_user.jelle.chapters : chapter.name => chapter.role }
+}
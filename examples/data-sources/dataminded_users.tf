@@ -0,0 +1,10 @@
+data "dataminded_users" "platform" {
+  name_prefix = "platform-"
+}
+
+resource "dataminded_chapter_member" "platform" {
+  for_each = data.dataminded_users.platform.by_name
+
+  chapter = dataminded_chapter.platform_chapter.id
+  member  = each.value.id
+}
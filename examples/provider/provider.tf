@@ -7,6 +7,5 @@ terraform {
 }
 
 provider "dataminded" {
-  host = "http://localhost"
-  port = 3000
+  endpoint = "http://localhost:3000"
 }
@@ -1,7 +1,9 @@
 package acceptance
 
 import (
+	"fmt"
 	"math/rand"
+	"net/url"
 	"os"
 	"strconv"
 	"testing"
@@ -13,8 +15,8 @@ const (
 )
 
 type TestData struct {
-	Host string
-	Port int64
+	// Endpoint is the URL of the API under test
+	Endpoint *url.URL
 
 	// RandomInteger is a random integer which is unique to this test case
 	RandomInteger int
@@ -25,8 +27,7 @@ type TestData struct {
 
 func BuildTestData(t *testing.T) TestData {
 	testData := TestData{
-		Host:          "http://localhost",
-		Port:          apiPort(),
+		Endpoint:      &url.URL{Scheme: "http", Host: fmt.Sprintf("localhost:%d", apiPort())},
 		RandomInteger: rand.Intn(1000000) + 99999,
 		RandomString:  randString(5),
 	}
@@ -0,0 +1,70 @@
+package acceptance
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"path/filepath"
+	"testing"
+)
+
+// AuthenticatingProxy starts a reverse proxy in front of the API that stands in for an
+// API gateway: requests for which authorized returns false are rejected with a 401.
+// It returns a copy of data that points at the proxy. The proxy is stopped when the
+// test ends.
+func AuthenticatingProxy(t *testing.T, data TestData, authorized func(r *http.Request) bool) TestData {
+	proxy := httputil.NewSingleHostReverseProxy(data.Endpoint)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(r) {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		proxy.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	serverUrl, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data.Endpoint = serverUrl
+	return data
+}
+
+// PathPrefixProxy starts a reverse proxy that serves the API under prefix, like an
+// ingress that mounts it on a sub-path. It returns a copy of data that points at the
+// proxy. The proxy is stopped when the test ends.
+func PathPrefixProxy(t *testing.T, data TestData, prefix string) TestData {
+	server := httptest.NewServer(http.StripPrefix(prefix, httputil.NewSingleHostReverseProxy(data.Endpoint)))
+	t.Cleanup(server.Close)
+
+	serverUrl, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data.Endpoint = serverUrl.JoinPath(prefix)
+	return data
+}
+
+// UnixSocketProxy starts a reverse proxy that serves the API on a unix socket, like a
+// sidecar. It returns a copy of data that points at the socket. The proxy is stopped
+// when the test ends.
+func UnixSocketProxy(t *testing.T, data TestData) TestData {
+	socket := filepath.Join(t.TempDir(), "api.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewUnstartedServer(httputil.NewSingleHostReverseProxy(data.Endpoint))
+	server.Listener = listener
+	server.Start()
+	t.Cleanup(server.Close)
+
+	data.Endpoint = &url.URL{Scheme: "unix", Path: socket}
+	return data
+}
@@ -1,10 +1,13 @@
 package acceptance
 
 import (
+	"testing"
+
 	"terraform-provider-dataminded/internal/provider"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 var (
@@ -12,3 +15,11 @@ var (
 		"dataminded": providerserver.NewProtocol6WithError(provider.New("test")()),
 	}
 )
+
+// ImportTest runs a test case with import steps. It sets the provider for the whole
+// test case rather than per step: with providers set per step, the import steps leave
+// the destroy at the end of the test without a provider block.
+func ImportTest(t *testing.T, testCase resource.TestCase) {
+	testCase.ProtoV6ProviderFactories = TestAccProtoV6ProviderFactories
+	resource.Test(t, testCase)
+}
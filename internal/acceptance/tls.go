@@ -0,0 +1,106 @@
+package acceptance
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// TLSTestData is TestData for an API served over HTTPS by TLSProxy.
+type TLSTestData struct {
+	TestData
+
+	// CACertPEM verifies the certificate of the proxy.
+	CACertPEM string
+
+	// ClientCertPEM and ClientKeyPEM are accepted by a proxy that requires mutual TLS.
+	ClientCertPEM string
+	ClientKeyPEM  string
+}
+
+// TLSProxy starts a reverse proxy that serves the API over HTTPS with a self-signed
+// certificate. When requireClientCert is set, the proxy only accepts connections that
+// present the client certificate in the returned data. The proxy is stopped when the
+// test ends.
+func TLSProxy(t *testing.T, data TestData, requireClientCert bool) TLSTestData {
+	clientCA, clientCertPEM, clientKeyPEM := clientCertificate(t)
+
+	server := httptest.NewUnstartedServer(httputil.NewSingleHostReverseProxy(data.Endpoint))
+	if requireClientCert {
+		server.TLS = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			ClientAuth: tls.RequireAndVerifyClientCert,
+			ClientCAs:  clientCA,
+		}
+	}
+	// Silence the failed handshakes that the tests provoke on purpose
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	serverUrl, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data.Endpoint = serverUrl
+	return TLSTestData{
+		TestData:      data,
+		CACertPEM:     string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})),
+		ClientCertPEM: clientCertPEM,
+		ClientKeyPEM:  clientKeyPEM,
+	}
+}
+
+// clientCertificate creates a self-signed client certificate and the pool to verify it.
+func clientCertificate(t *testing.T) (*x509.CertPool, string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "terraform-provider-dataminded"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(certificate)
+
+	return pool,
+		string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+}
@@ -0,0 +1,39 @@
+package dataminded_api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DefaultApiKeyHeader is the header that carries the API key when no other header is configured.
+const DefaultApiKeyHeader = "X-API-Key"
+
+// authenticate adds the credentials of the connection, if any, to request.
+func (c Connection) authenticate(request *http.Request) {
+	if c.Token != "" {
+		request.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	if c.ApiKey != "" {
+		header := c.ApiKeyHeader
+		if header == "" {
+			header = DefaultApiKeyHeader
+		}
+		request.Header.Set(header, c.ApiKey)
+	}
+}
+
+// withRedactedCredentials returns a context whose log entries have the credentials of
+// the connection masked, so that they never end up in TF_LOG output.
+func (c Connection) withRedactedCredentials(ctx context.Context) context.Context {
+	for _, secret := range []string{c.Token, c.ApiKey} {
+		if secret != "" {
+			ctx = tflog.MaskAllFieldValuesStrings(ctx, secret)
+			ctx = tflog.MaskMessageStrings(ctx, secret)
+		}
+	}
+
+	return ctx
+}
@@ -0,0 +1,88 @@
+package dataminded_api_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+
+	"terraform-provider-dataminded/internal/dataminded_api"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// headerConnection returns a connection to a stand-in API that records the headers of
+// the last request it received.
+func headerConnection(t *testing.T) (dataminded_api.Connection, *http.Header) {
+	var headers http.Header
+
+	connection := stubConnection(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		_, _ = w.Write([]byte(`{"id": 1, "name": "Jonathan"}`))
+	}))
+
+	return connection, &headers
+}
+
+func TestNoCredentials(t *testing.T) {
+	connection, headers := headerConnection(t)
+
+	_, err := dataminded_api.NewClient(connection).ReadUser(t.Context(), 1)
+
+	assert.Nil(t, err)
+	assert.Empty(t, headers.Get("Authorization"))
+	assert.Empty(t, headers.Get(dataminded_api.DefaultApiKeyHeader))
+}
+
+func TestBearerToken(t *testing.T) {
+	connection, headers := headerConnection(t)
+	connection.Token = "s3cr3t"
+
+	_, err := dataminded_api.NewClient(connection).CreateUser(t.Context(), "Jonathan")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Bearer s3cr3t", headers.Get("Authorization"))
+}
+
+func TestApiKeyDefaultHeader(t *testing.T) {
+	connection, headers := headerConnection(t)
+	connection.ApiKey = "k3y"
+
+	_, err := dataminded_api.NewClient(connection).ReadUser(t.Context(), 1)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "k3y", headers.Get("X-API-Key"))
+}
+
+func TestApiKeyCustomHeader(t *testing.T) {
+	connection, headers := headerConnection(t)
+	connection.ApiKey = "k3y"
+	connection.ApiKeyHeader = "X-Gateway-Key"
+
+	err := dataminded_api.NewClient(connection).DeleteUser(t.Context(), 1)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "k3y", headers.Get("X-Gateway-Key"))
+	assert.Empty(t, headers.Get(dataminded_api.DefaultApiKeyHeader))
+}
+
+func TestCredentialsAreRedactedFromLogs(t *testing.T) {
+	// A misbehaving gateway that echoes the credentials it rejected
+	connection := stubConnection(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rejected "+r.Header.Get("Authorization")+" "+r.Header.Get("X-API-Key"), http.StatusServiceUnavailable)
+	}))
+	connection.Token = "s3cr3t-token"
+	connection.ApiKey = "s3cr3t-key"
+	connection.Retry = dataminded_api.RetryPolicy{MaxRetries: 1, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(t.Context(), &output)
+
+	_, err := dataminded_api.NewClient(connection).ReadUser(ctx, 1)
+
+	assert.NotNil(t, err)
+	assert.Contains(t, output.String(), "Retrying request")
+	assert.NotContains(t, output.String(), "s3cr3t-token")
+	assert.NotContains(t, output.String(), "s3cr3t-key")
+}
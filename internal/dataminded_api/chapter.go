@@ -1,11 +1,9 @@
 package dataminded_api
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
+	"context"
+
+	"terraform-provider-dataminded/internal/dataminded_api/openapi"
 )
 
 type Chapter struct {
@@ -13,138 +11,55 @@ type Chapter struct {
 	Name string
 }
 
-func ListChapters(connection Connection) ([]Chapter, error) {
-	response, err := http.Get(fmt.Sprintf("%s/chapter", baseUrl(connection)))
-	if err != nil {
-		return nil, err
+func chapterFromApi(chapter openapi.Chapter) Chapter {
+	return Chapter{
+		Id:   int(chapter.Id),
+		Name: chapter.Name,
 	}
+}
 
-	responseData, err := io.ReadAll(response.Body)
+func (c *Client) ListChapters(ctx context.Context) ([]Chapter, error) {
+	chapters, err := c.api.ListChapters(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var chapters []Chapter
-	err = json.Unmarshal(responseData, &chapters)
-	if err != nil {
-		return nil, err
+	result := make([]Chapter, 0, len(chapters))
+	for _, chapter := range chapters {
+		result = append(result, chapterFromApi(chapter))
 	}
 
-	return chapters, nil
+	return result, nil
 }
 
-func CreateChapter(connection Connection, name string) (Chapter, error) {
-	body := []byte(fmt.Sprintf(`{
-		"name": "%s"
-	}`, name))
-
-	response, err := http.Post(
-		fmt.Sprintf("%s/chapter", baseUrl(connection)),
-		"application/json",
-		bytes.NewBuffer(body),
-	)
-
-	if err != nil {
-		return Chapter{}, err
-	}
-
-	responseData, err := io.ReadAll(response.Body)
-	if err != nil {
-		return Chapter{}, err
-	}
-
-	var chapter Chapter
-	err = json.Unmarshal(responseData, &chapter)
+func (c *Client) CreateChapter(ctx context.Context, name string) (Chapter, error) {
+	chapter, err := c.api.CreateChapter(ctx, openapi.NewChapter{Name: name})
 	if err != nil {
 		return Chapter{}, err
 	}
 
-	return chapter, nil
+	return chapterFromApi(chapter), nil
 }
 
-func ReadChapter(connection Connection, id int) (Chapter, error) {
-	response, err := http.Get(fmt.Sprintf("%s/chapter/%d", baseUrl(connection), id))
-	if err != nil {
-		return Chapter{}, err
-	}
-
-	responseData, err := io.ReadAll(response.Body)
-	if err != nil {
-		return Chapter{}, err
-	}
-
-	if string(responseData) == ERROR_USER_NOT_FOUND {
-		return Chapter{
-			Id: -1,
-		}, nil
-	}
-
-	var chapter Chapter
-	err = json.Unmarshal(responseData, &chapter)
+func (c *Client) ReadChapter(ctx context.Context, id int) (Chapter, error) {
+	chapter, err := c.api.GetChapter(ctx, int32(id))
 	if err != nil {
 		return Chapter{}, err
 	}
 
-	return chapter, nil
+	return chapterFromApi(chapter), nil
 }
 
-func UpdateChapter(connection Connection, id int, name string) (Chapter, error) {
-	body := []byte(fmt.Sprintf(`{
-		"name": "%s"
-	}`, name))
-
-	request, err := http.NewRequest(http.MethodPut,
-		fmt.Sprintf("%s/chapter/%d", baseUrl(connection), id),
-		bytes.NewBuffer(body),
-	)
-	request.Header.Set("Content-Type", "application/json")
-
-	if err != nil {
-		return Chapter{}, err
-	}
-
-	response, err := (&http.Client{}).Do(request)
-	if err != nil {
-		return Chapter{}, err
-	}
-
-	responseData, err := io.ReadAll(response.Body)
-	if err != nil {
-		return Chapter{}, err
-	}
-
-	var chapter Chapter
-	err = json.Unmarshal(responseData, &chapter)
+func (c *Client) UpdateChapter(ctx context.Context, id int, name string) (Chapter, error) {
+	chapter, err := c.api.UpdateChapter(ctx, int32(id), openapi.NewChapter{Name: name})
 	if err != nil {
 		return Chapter{}, err
 	}
 
-	return chapter, nil
-}
-
-func DeleteChapter(connection Connection, id int) error {
-
-	request, err := http.NewRequest(http.MethodDelete,
-		fmt.Sprintf("%s/chapter/%d", baseUrl(connection), id),
-		nil,
-	)
-
-	if err != nil {
-		return err
-	}
-
-	response, err := (&http.Client{}).Do(request)
-	if err != nil {
-		return err
-	}
-
-	if response.StatusCode != 200 {
-		return fmt.Errorf("non 200 status code when deleting chapter %d", id)
-	}
-
-	return nil
+	return chapterFromApi(chapter), nil
 }
 
-func ChapterExists(chapter Chapter) bool {
-	return chapter.Id != -1
+func (c *Client) DeleteChapter(ctx context.Context, id int) error {
+	_, err := c.api.DeleteChapter(ctx, int32(id))
+	return err
 }
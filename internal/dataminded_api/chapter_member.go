@@ -1,129 +1,113 @@
 package dataminded_api
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
+	"context"
+
+	"terraform-provider-dataminded/internal/dataminded_api/openapi"
+)
+
+// The roles a member can have in a chapter.
+const (
+	RoleLead        = string(openapi.ChapterRoleLead)
+	RoleContributor = string(openapi.ChapterRoleContributor)
 )
 
+// Roles lists the roles a member can have in a chapter.
+var Roles = []string{RoleLead, RoleContributor}
+
 type ChapterMember struct {
 	ChapterId int
 	UserId    int
 	Role      string
 }
 
-func ReadChapterMember(connection Connection, chapterId int, userId int) (ChapterMember, error) {
-	response, err := http.Get(fmt.Sprintf("%s/chapter/%d/member/%d", baseUrl(connection), chapterId, userId))
-	if err != nil {
-		return ChapterMember{}, err
+func chapterMemberFromApi(member openapi.ChapterMember) ChapterMember {
+	// The API stores Contributor when a member is created without a role
+	role := openapi.ChapterRoleContributor
+	if member.Role != nil {
+		role = *member.Role
 	}
 
-	responseData, err := io.ReadAll(response.Body)
-	if err != nil {
-		return ChapterMember{}, err
+	return ChapterMember{
+		ChapterId: int(member.ChapterId),
+		UserId:    int(member.UserId),
+		Role:      string(role),
 	}
-
-	if string(responseData) == ERROR_CHAPTER_MEMBER_NOT_FOUND {
-		return ChapterMember{
-			UserId:    -1,
-			ChapterId: -1,
-		}, nil
-	}
-
-	var member ChapterMember
-	err = json.Unmarshal(responseData, &member)
-	if err != nil {
-		return ChapterMember{}, err
-	}
-
-	return member, nil
 }
 
-func CreateChapterMember(connection Connection, chapterId int, userId int, role string) error {
-
-	body := []byte(fmt.Sprintf(`{
-			"role": "%s"
-		}`, role))
-
-	response, err := http.Post(
-		fmt.Sprintf("%s/chapter/%d/member/%d", baseUrl(connection), chapterId, userId),
-		"application/json",
-		bytes.NewBuffer(body),
-	)
-
-	if err != nil {
-		return err
-	}
+func newChapterMember(role string) openapi.NewChapterMember {
+	chapterRole := openapi.ChapterRole(role)
+	return openapi.NewChapterMember{Role: &chapterRole}
+}
 
-	responseData, err := io.ReadAll(response.Body)
+// ListChapterMembers returns the members of a chapter.
+func (c *Client) ListChapterMembers(ctx context.Context, chapterId int) ([]ChapterMember, error) {
+	members, err := c.api.ListChapterMembersInChapter(ctx, int32(chapterId))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if response.StatusCode >= 400 {
-		return fmt.Errorf("non 200 status code when creating chapter member %d/%d. Detailed error: %s", chapterId, userId, string(responseData))
+	result := make([]ChapterMember, 0, len(members))
+	for _, member := range members {
+		result = append(result, chapterMemberFromApi(member))
 	}
 
-	return nil
+	return result, nil
 }
 
-func UpdateChapterMember(connection Connection, chapterId int, userId int, role string) error {
-	body := []byte(fmt.Sprintf(`{
-		"role": "%s"
-	}`, role))
-
-	request, err := http.NewRequest(http.MethodPut,
-		fmt.Sprintf("%s/chapter/%d/member/%d", baseUrl(connection), chapterId, userId),
-		bytes.NewBuffer(body),
-	)
-	request.Header.Set("Content-Type", "application/json")
-
+// ListAllChapterMembers returns the members of every chapter.
+func (c *Client) ListAllChapterMembers(ctx context.Context) ([]ChapterMember, error) {
+	members, err := c.api.ListChapterMembers(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	response, err := (&http.Client{}).Do(request)
-	if err != nil {
-		return err
+	result := make([]ChapterMember, 0, len(members))
+	for _, member := range members {
+		result = append(result, chapterMemberFromApi(member))
 	}
 
-	responseData, err := io.ReadAll(response.Body)
+	return result, nil
+}
+
+// ListMembershipsForUser returns the memberships of a user in every chapter. The
+// API cannot filter by user, so this lists all members in one request.
+func (c *Client) ListMembershipsForUser(ctx context.Context, userId int) ([]ChapterMember, error) {
+	members, err := c.ListAllChapterMembers(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if response.StatusCode >= 400 {
-		return fmt.Errorf("non 200 status code when updating chapter member %d/%d. Detailed error: %s", chapterId, userId, string(responseData))
+	result := []ChapterMember{}
+	for _, member := range members {
+		if member.UserId == userId {
+			result = append(result, member)
+		}
 	}
 
-	return nil
+	return result, nil
 }
 
-func DeleteChapterMember(connection Connection, chapterId int, userId int) error {
-
-	request, err := http.NewRequest(http.MethodDelete,
-		fmt.Sprintf("%s/chapter/%d/member/%d", baseUrl(connection), chapterId, userId),
-		nil,
-	)
-
+func (c *Client) ReadChapterMember(ctx context.Context, chapterId int, userId int) (ChapterMember, error) {
+	member, err := c.api.GetChapterMember(ctx, int32(chapterId), int32(userId))
 	if err != nil {
-		return err
+		return ChapterMember{}, err
 	}
 
-	response, err := (&http.Client{}).Do(request)
-	if err != nil {
-		return err
-	}
+	return chapterMemberFromApi(member), nil
+}
 
-	if response.StatusCode != 200 {
-		return fmt.Errorf("non 200 status code when deleting chapter member %d/%d", chapterId, userId)
-	}
+func (c *Client) CreateChapterMember(ctx context.Context, chapterId int, userId int, role string) error {
+	_, err := c.api.CreateChapterMember(ctx, int32(chapterId), int32(userId), newChapterMember(role))
+	return err
+}
 
-	return nil
+func (c *Client) UpdateChapterMember(ctx context.Context, chapterId int, userId int, role string) error {
+	_, err := c.api.UpdateChapterMember(ctx, int32(chapterId), int32(userId), newChapterMember(role))
+	return err
 }
 
-func ChapterMemberExists(member ChapterMember) bool {
-	return member.UserId != -1
+func (c *Client) DeleteChapterMember(ctx context.Context, chapterId int, userId int) error {
+	_, err := c.api.DeleteChapterMember(ctx, int32(chapterId), int32(userId))
+	return err
 }
@@ -11,90 +11,219 @@ import (
 
 func TestCreateChapterMember(t *testing.T) {
 	data := acceptance.BuildTestData(t)
-	connection := dataminded_api.Connection{
-		Host: data.Host,
-		Port: data.Port,
-	}
+	client := dataminded_api.NewClient(dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	})
 
-	user, err := dataminded_api.CreateUser(connection, data.RandomString)
+	user, err := client.CreateUser(t.Context(), data.RandomString)
 	assert.Nil(t, err)
 
-	chapter, err := dataminded_api.CreateChapter(connection, data.RandomString)
+	chapter, err := client.CreateChapter(t.Context(), data.RandomString)
 	assert.Nil(t, err)
 
 	role := "Lead"
-	err = dataminded_api.CreateChapterMember(connection, chapter.Id, user.Id, role)
+	err = client.CreateChapterMember(t.Context(), chapter.Id, user.Id, role)
 	assert.Nil(t, err)
 
 	// Test that the chapter member is read correctly
-	member, err := dataminded_api.ReadChapterMember(connection, chapter.Id, user.Id)
+	member, err := client.ReadChapterMember(t.Context(), chapter.Id, user.Id)
 	assert.Nil(t, err)
 
-	assert.Equal(t, role, member.Role)
+	assert.Equal(t, dataminded_api.ChapterMember{ChapterId: chapter.Id, UserId: user.Id, Role: role}, member)
 }
 
 func TestReadNonExistentChapterMember(t *testing.T) {
 	data := acceptance.BuildTestData(t)
-	connection := dataminded_api.Connection{
-		Host: data.Host,
-		Port: data.Port,
-	}
+	client := dataminded_api.NewClient(dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	})
 
 	t.Log(data.RandomInteger)
-	chapterMember, err := dataminded_api.ReadChapterMember(connection, data.RandomInteger, data.RandomInteger)
+	_, err := client.ReadChapterMember(t.Context(), data.RandomInteger, data.RandomInteger)
 
-	assert.Nil(t, err)
-	assert.Equal(t, chapterMember.ChapterId, -1)
+	assert.ErrorIs(t, err, dataminded_api.ErrNotFound)
 }
 
 func TestUpdateChapterMember(t *testing.T) {
 	data := acceptance.BuildTestData(t)
-	connection := dataminded_api.Connection{
-		Host: data.Host,
-		Port: data.Port,
-	}
+	client := dataminded_api.NewClient(dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	})
 
-	user, err := dataminded_api.CreateUser(connection, data.RandomString)
+	user, err := client.CreateUser(t.Context(), data.RandomString)
 	assert.Nil(t, err)
 
-	chapter, err := dataminded_api.CreateChapter(connection, data.RandomString)
+	chapter, err := client.CreateChapter(t.Context(), data.RandomString)
 	assert.Nil(t, err)
 
 	initialRole := "Lead"
 	newRole := "Contributor"
-	err = dataminded_api.CreateChapterMember(connection, chapter.Id, user.Id, initialRole)
+	err = client.CreateChapterMember(t.Context(), chapter.Id, user.Id, initialRole)
 	assert.Nil(t, err)
 
-	err = dataminded_api.UpdateChapterMember(connection, chapter.Id, user.Id, newRole)
+	err = client.UpdateChapterMember(t.Context(), chapter.Id, user.Id, newRole)
 	assert.Nil(t, err)
 
-	member, err := dataminded_api.ReadChapterMember(connection, chapter.Id, user.Id)
+	member, err := client.ReadChapterMember(t.Context(), chapter.Id, user.Id)
 	assert.Nil(t, err)
 	assert.Equal(t, newRole, member.Role)
 }
 
 func TestDeleteChapterMember(t *testing.T) {
 	data := acceptance.BuildTestData(t)
-	connection := dataminded_api.Connection{
-		Host: data.Host,
-		Port: data.Port,
-	}
+	client := dataminded_api.NewClient(dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	})
+
+	user, err := client.CreateUser(t.Context(), data.RandomString)
+	assert.Nil(t, err)
+
+	chapter, err := client.CreateChapter(t.Context(), data.RandomString)
+	assert.Nil(t, err)
+
+	err = client.CreateChapterMember(t.Context(), chapter.Id, user.Id, "Contributor")
+	assert.Nil(t, err)
+
+	err = client.DeleteChapterMember(t.Context(), chapter.Id, user.Id)
+	assert.Nil(t, err)
+
+	// check that the chapter member no longer exists
+	_, err = client.ReadChapterMember(t.Context(), chapter.Id, user.Id)
+
+	assert.ErrorIs(t, err, dataminded_api.ErrNotFound)
+}
+
+func TestCreateDuplicateChapterMember(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	client := dataminded_api.NewClient(dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	})
+
+	user, err := client.CreateUser(t.Context(), data.RandomString)
+	assert.Nil(t, err)
+
+	chapter, err := client.CreateChapter(t.Context(), data.RandomString)
+	assert.Nil(t, err)
+
+	err = client.CreateChapterMember(t.Context(), chapter.Id, user.Id, "Contributor")
+	assert.Nil(t, err)
+
+	err = client.CreateChapterMember(t.Context(), chapter.Id, user.Id, "Lead")
+	assert.ErrorIs(t, err, dataminded_api.ErrConflict)
+}
+
+func TestListChapterMembers(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	client := dataminded_api.NewClient(dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	})
 
-	user, err := dataminded_api.CreateUser(connection, data.RandomString)
+	lead, err := client.CreateUser(t.Context(), data.RandomString)
 	assert.Nil(t, err)
 
-	chapter, err := dataminded_api.CreateChapter(connection, data.RandomString)
+	contributor, err := client.CreateUser(t.Context(), data.RandomString)
 	assert.Nil(t, err)
 
-	err = dataminded_api.CreateChapterMember(connection, chapter.Id, user.Id, "Contributor")
+	chapter, err := client.CreateChapter(t.Context(), data.RandomString)
 	assert.Nil(t, err)
 
-	err = dataminded_api.DeleteChapterMember(connection, chapter.Id, user.Id)
+	other, err := client.CreateChapter(t.Context(), data.RandomString)
 	assert.Nil(t, err)
 
-	// check that the chapter no longer exists
-	member, err := dataminded_api.ReadChapterMember(connection, chapter.Id, user.Id)
+	assert.Nil(t, client.CreateChapterMember(t.Context(), chapter.Id, lead.Id, "Lead"))
+	assert.Nil(t, client.CreateChapterMember(t.Context(), chapter.Id, contributor.Id, "Contributor"))
+	assert.Nil(t, client.CreateChapterMember(t.Context(), other.Id, lead.Id, "Contributor"))
+
+	members, err := client.ListChapterMembers(t.Context(), chapter.Id)
+	assert.Nil(t, err)
+
+	assert.ElementsMatch(t, []dataminded_api.ChapterMember{
+		{ChapterId: chapter.Id, UserId: lead.Id, Role: "Lead"},
+		{ChapterId: chapter.Id, UserId: contributor.Id, Role: "Contributor"},
+	}, members)
+}
+
+func TestListChapterMembersOfEmptyChapter(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	client := dataminded_api.NewClient(dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	})
+
+	chapter, err := client.CreateChapter(t.Context(), data.RandomString)
+	assert.Nil(t, err)
+
+	members, err := client.ListChapterMembers(t.Context(), chapter.Id)
+	assert.Nil(t, err)
+	assert.Empty(t, members)
+}
+
+func TestListAllChapterMembers(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	client := dataminded_api.NewClient(dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	})
+
+	user, err := client.CreateUser(t.Context(), data.RandomString)
+	assert.Nil(t, err)
+
+	platform, err := client.CreateChapter(t.Context(), data.RandomString)
+	assert.Nil(t, err)
+
+	engineering, err := client.CreateChapter(t.Context(), data.RandomString)
+	assert.Nil(t, err)
+
+	assert.Nil(t, client.CreateChapterMember(t.Context(), platform.Id, user.Id, "Lead"))
+	assert.Nil(t, client.CreateChapterMember(t.Context(), engineering.Id, user.Id, "Contributor"))
+
+	members, err := client.ListAllChapterMembers(t.Context())
+	assert.Nil(t, err)
+
+	// Other tests share the API, so only check that both memberships are listed
+	assert.Contains(t, members, dataminded_api.ChapterMember{ChapterId: platform.Id, UserId: user.Id, Role: "Lead"})
+	assert.Contains(t, members, dataminded_api.ChapterMember{ChapterId: engineering.Id, UserId: user.Id, Role: "Contributor"})
+}
+
+func TestListMembershipsForUser(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	client := dataminded_api.NewClient(dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	})
+
+	user, err := client.CreateUser(t.Context(), data.RandomString)
+	assert.Nil(t, err)
+
+	other, err := client.CreateUser(t.Context(), data.RandomString)
+	assert.Nil(t, err)
+
+	platform, err := client.CreateChapter(t.Context(), data.RandomString)
+	assert.Nil(t, err)
+
+	engineering, err := client.CreateChapter(t.Context(), data.RandomString)
+	assert.Nil(t, err)
+
+	assert.Nil(t, client.CreateChapterMember(t.Context(), platform.Id, user.Id, "Lead"))
+	assert.Nil(t, client.CreateChapterMember(t.Context(), engineering.Id, user.Id, "Contributor"))
+	assert.Nil(t, client.CreateChapterMember(t.Context(), platform.Id, other.Id, "Contributor"))
+
+	memberships, err := client.ListMembershipsForUser(t.Context(), user.Id)
+	assert.Nil(t, err)
+
+	assert.ElementsMatch(t, []dataminded_api.ChapterMember{
+		{ChapterId: platform.Id, UserId: user.Id, Role: "Lead"},
+		{ChapterId: engineering.Id, UserId: user.Id, Role: "Contributor"},
+	}, memberships)
+}
+
+func TestListMembershipsForUserWithoutChapters(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	client := dataminded_api.NewClient(dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	})
+
+	user, err := client.CreateUser(t.Context(), data.RandomString)
+	assert.Nil(t, err)
 
+	memberships, err := client.ListMembershipsForUser(t.Context(), user.Id)
 	assert.Nil(t, err)
-	assert.Equal(t, member.ChapterId, -1)
+	assert.Empty(t, memberships)
 }
@@ -12,12 +12,11 @@ import (
 
 func TestCreateChapter(t *testing.T) {
 	data := acceptance.BuildTestData(t)
-	connection := dataminded_api.Connection{
-		Host: data.Host,
-		Port: data.Port,
-	}
+	client := dataminded_api.NewClient(dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	})
 
-	chapter, err := dataminded_api.CreateChapter(connection, data.RandomString)
+	chapter, err := client.CreateChapter(t.Context(), data.RandomString)
 
 	if err != nil {
 		t.Log(err)
@@ -26,7 +25,7 @@ func TestCreateChapter(t *testing.T) {
 	assert.Equal(t, data.RandomString, chapter.Name)
 
 	// Test that the chapter is read correctly
-	chapter, err = dataminded_api.ReadChapter(connection, chapter.Id)
+	chapter, err = client.ReadChapter(t.Context(), chapter.Id)
 	if err != nil {
 		t.Log(err)
 	}
@@ -35,7 +34,7 @@ func TestCreateChapter(t *testing.T) {
 
 	// Test that the chapter is in the list of chapters
 	var chapters []dataminded_api.Chapter
-	chapters, err = dataminded_api.ListChapters(connection)
+	chapters, err = client.ListChapters(t.Context())
 	if err != nil {
 		t.Log(err)
 	}
@@ -53,29 +52,23 @@ func TestCreateChapter(t *testing.T) {
 
 func TestReadNonExistentChapter(t *testing.T) {
 	data := acceptance.BuildTestData(t)
-	connection := dataminded_api.Connection{
-		Host: data.Host,
-		Port: data.Port,
-	}
+	client := dataminded_api.NewClient(dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	})
 
 	t.Log(data.RandomInteger)
-	chapter, err := dataminded_api.ReadChapter(connection, data.RandomInteger)
+	_, err := client.ReadChapter(t.Context(), data.RandomInteger)
 
-	if err != nil {
-		t.Log(err)
-	}
-	assert.Nil(t, err)
-	assert.Equal(t, chapter.Id, -1)
+	assert.ErrorIs(t, err, dataminded_api.ErrNotFound)
 }
 
 func TestListChapters(t *testing.T) {
 	data := acceptance.BuildTestData(t)
-	connection := dataminded_api.Connection{
-		Host: data.Host,
-		Port: data.Port,
-	}
+	client := dataminded_api.NewClient(dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	})
 
-	_, err := dataminded_api.ListChapters(connection)
+	_, err := client.ListChapters(t.Context())
 
 	if err != nil {
 		t.Log(err)
@@ -85,15 +78,14 @@ func TestListChapters(t *testing.T) {
 
 func TestUpdateChapter(t *testing.T) {
 	data := acceptance.BuildTestData(t)
-	connection := dataminded_api.Connection{
-		Host: data.Host,
-		Port: data.Port,
-	}
+	client := dataminded_api.NewClient(dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	})
 
 	originalName := data.RandomString
 	newName := fmt.Sprintf("%s-new", originalName)
 
-	chapter, err := dataminded_api.CreateChapter(connection, originalName)
+	chapter, err := client.CreateChapter(t.Context(), originalName)
 	originalId := chapter.Id
 
 	if err != nil {
@@ -102,7 +94,7 @@ func TestUpdateChapter(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, originalName, chapter.Name)
 
-	chapter, err = dataminded_api.UpdateChapter(connection, originalId, newName)
+	chapter, err = client.UpdateChapter(t.Context(), originalId, newName)
 	if err != nil {
 		t.Log(err)
 	}
@@ -110,7 +102,7 @@ func TestUpdateChapter(t *testing.T) {
 	assert.Equal(t, newName, chapter.Name)
 
 	// check that if we read the originalId we obtain the new name
-	chapter, err = dataminded_api.ReadChapter(connection, originalId)
+	chapter, err = client.ReadChapter(t.Context(), originalId)
 	if err != nil {
 		t.Log(err)
 	}
@@ -120,12 +112,11 @@ func TestUpdateChapter(t *testing.T) {
 
 func TestDeleteChapter(t *testing.T) {
 	data := acceptance.BuildTestData(t)
-	connection := dataminded_api.Connection{
-		Host: data.Host,
-		Port: data.Port,
-	}
+	client := dataminded_api.NewClient(dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	})
 
-	chapter, err := dataminded_api.CreateChapter(connection, data.RandomString)
+	chapter, err := client.CreateChapter(t.Context(), data.RandomString)
 
 	if err != nil {
 		t.Log(err)
@@ -133,18 +124,31 @@ func TestDeleteChapter(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, data.RandomString, chapter.Name)
 
-	err = dataminded_api.DeleteChapter(connection, chapter.Id)
+	err = client.DeleteChapter(t.Context(), chapter.Id)
 	if err != nil {
 		t.Log(err)
 	}
 	assert.Nil(t, err)
 
 	// check that the chapter no longer exists
-	chapter, err = dataminded_api.ReadChapter(connection, data.RandomInteger)
+	_, err = client.ReadChapter(t.Context(), chapter.Id)
 
-	if err != nil {
-		t.Log(err)
+	assert.ErrorIs(t, err, dataminded_api.ErrNotFound)
+}
+
+func TestChapterHostileNames(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	client := dataminded_api.NewClient(dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	})
+
+	for _, name := range hostileNames {
+		chapter, err := client.CreateChapter(t.Context(), name)
+		assert.Nil(t, err, name)
+		assert.Equal(t, name, chapter.Name)
+
+		chapter, err = client.ReadChapter(t.Context(), chapter.Id)
+		assert.Nil(t, err, name)
+		assert.Equal(t, name, chapter.Name)
 	}
-	assert.Nil(t, err)
-	assert.Equal(t, chapter.Id, -1)
 }
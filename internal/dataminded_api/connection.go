@@ -1,12 +1,151 @@
 package dataminded_api
 
-import "fmt"
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"terraform-provider-dataminded/internal/dataminded_api/openapi"
+
+	"github.com/hashicorp/go-cleanhttp"
+)
+
+// DefaultTimeout bounds a single request to the API, including reading the response body.
+const DefaultTimeout = 30 * time.Second
 
 type Connection struct {
-	Host string
-	Port int64
+	// Endpoint is the URL of the API, as returned by ParseEndpoint.
+	Endpoint *url.URL
+	Retry    RetryPolicy
+
+	// Token is sent as a bearer token in the Authorization header, when set.
+	Token string
+	// ApiKey is sent in the ApiKeyHeader header, or DefaultApiKeyHeader, when set.
+	ApiKey       string
+	ApiKeyHeader string
+
+	// TLSConfig is used for HTTPS connections to the API, when set.
+	TLSConfig *tls.Config
+}
+
+// Client talks to the Dataminded API. It is built once by the provider and shared by
+// all resources, so that they reuse the same connection pool.
+type Client struct {
+	connection Connection
+	httpClient *http.Client
+	api        openapi.Operations
 }
 
-func baseUrl(connection Connection) string {
-	return fmt.Sprintf("%s:%d", connection.Host, connection.Port)
+func NewClient(connection Connection) *Client {
+	transport := cleanhttp.DefaultPooledTransport()
+	transport.TLSClientConfig = connection.TLSConfig
+	if connection.Endpoint.Scheme == "unix" {
+		transport.DialContext = dialUnix(connection.Endpoint.Path)
+	}
+
+	client := &Client{
+		connection: connection,
+		httpClient: &http.Client{
+			Transport: transport,
+			Timeout:   DefaultTimeout,
+		},
+	}
+	client.api = openapi.Operations{Do: client.doJSON}
+
+	return client
+}
+
+// doJSON sends request, if not nil, as a JSON body to the given path of the API and
+// decodes the JSON response into response, if not nil.
+func (c *Client) doJSON(ctx context.Context, method string, path string, request any, response any) error {
+	var body []byte
+	if request != nil {
+		var err error
+		body, err = json.Marshal(request)
+		if err != nil {
+			return fmt.Errorf("encoding request for %s %s: %w", method, path, err)
+		}
+	}
+
+	responseData, err := c.do(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+
+	if response == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(responseData, response); err != nil {
+		return fmt.Errorf("decoding response of %s %s: %w", method, path, err)
+	}
+
+	return nil
+}
+
+// do sends a request to the given path of the API and returns the fully read
+// response body. The request is aborted when ctx is cancelled. Responses
+// with a non-2xx status code are returned as an *APIError. Transient failures
+// are retried according to the RetryPolicy of the connection.
+func (c *Client) do(ctx context.Context, method string, path string, body []byte) ([]byte, error) {
+	ctx = c.connection.withRedactedCredentials(ctx)
+	url := baseUrl(c.connection.Endpoint) + path
+	retry := c.connection.Retry
+
+	for attempt := 0; ; attempt++ {
+		responseData, err := c.send(ctx, method, url, body)
+		if err == nil || attempt >= retry.MaxRetries || !retryable(method, err) {
+			return responseData, err
+		}
+
+		if err := retry.wait(ctx, method, url, attempt, err); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// send performs a single attempt of a request.
+func (c *Client) send(ctx context.Context, method string, url string, body []byte) ([]byte, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	c.connection.authenticate(request)
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	responseData, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, &APIError{
+			StatusCode: response.StatusCode,
+			Method:     method,
+			URL:        url,
+			Body:       string(responseData),
+		}
+	}
+
+	return responseData, nil
 }
@@ -1,4 +0,0 @@
-package dataminded_api
-
-const ERROR_USER_NOT_FOUND = "Record not found"
-const ERROR_CHAPTER_MEMBER_NOT_FOUND = "Record not found"
@@ -0,0 +1,76 @@
+package dataminded_api
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ParseEndpoint parses the URL of the API, such as http://localhost:3000 or
+// https://ingress.example.com/dataminded/v1. An API that listens on a unix socket
+// is reached with unix:///run/dataminded.sock, and a base path can be added to it
+// with unix:///run/dataminded.sock?base_path=/dataminded/v1.
+func ParseEndpoint(raw string) (*url.URL, error) {
+	endpoint, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parsing endpoint: %w", err)
+	}
+
+	switch endpoint.Scheme {
+	case "http", "https":
+		if endpoint.Host == "" {
+			return nil, fmt.Errorf("endpoint %q has no host", raw)
+		}
+
+		if port := endpoint.Port(); port != "" {
+			if number, err := strconv.Atoi(port); err != nil || number < 1 || number > 65535 {
+				return nil, fmt.Errorf("endpoint %q has port %s, expected a port between 1 and 65535", raw, port)
+			}
+		}
+	case "unix":
+		if endpoint.Host != "" || !strings.HasPrefix(endpoint.Path, "/") {
+			return nil, fmt.Errorf("endpoint %q must hold the absolute path of the socket, such as unix:///run/dataminded.sock", raw)
+		}
+
+		// Appended to the host of the requests, a relative base path would become part of it
+		if basePath := endpoint.Query().Get("base_path"); basePath != "" && !strings.HasPrefix(basePath, "/") {
+			return nil, fmt.Errorf("endpoint %q has base_path %q, expected an absolute path such as /dataminded/v1", raw, basePath)
+		}
+	case "":
+		return nil, fmt.Errorf("endpoint %q has no scheme, expected http://, https:// or unix://", raw)
+	default:
+		return nil, fmt.Errorf("endpoint %q has unsupported scheme %q, expected http, https or unix", raw, endpoint.Scheme)
+	}
+
+	return endpoint, nil
+}
+
+// HostPortEndpoint is the endpoint of the deprecated host and port attributes of the
+// provider, where the host carries the scheme.
+func HostPortEndpoint(host string, port int64) string {
+	return fmt.Sprintf("%s:%d", host, port)
+}
+
+// baseUrl returns the URL that the paths of the API are appended to.
+func baseUrl(endpoint *url.URL) string {
+	if endpoint.Scheme == "unix" {
+		// The host is ignored by dialUnix, but must be present in the request
+		return "http://unix" + strings.TrimSuffix(endpoint.Query().Get("base_path"), "/")
+	}
+
+	base := *endpoint
+	base.RawQuery = ""
+	base.Fragment = ""
+	return strings.TrimSuffix(base.String(), "/")
+}
+
+// dialUnix connects to the socket of a unix endpoint, whatever the address of the request.
+func dialUnix(socket string) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, _, _ string) (net.Conn, error) {
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, "unix", socket)
+	}
+}
@@ -0,0 +1,104 @@
+package dataminded_api_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"terraform-provider-dataminded/internal/dataminded_api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pathConnection returns a connection to a stand-in API that records the path of
+// the last request it received.
+func pathConnection(t *testing.T) (dataminded_api.Connection, *string) {
+	var requestPath string
+
+	connection := stubConnection(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.URL.Path
+		_, _ = w.Write([]byte(`{"id": 1, "name": "Jonathan"}`))
+	}))
+
+	return connection, &requestPath
+}
+
+func TestParseEndpoint(t *testing.T) {
+	for _, raw := range []string{
+		"http://localhost:3000",
+		"https://ingress.example.com/dataminded/v1",
+		"unix:///run/dataminded.sock",
+		"unix:///run/dataminded.sock?base_path=/dataminded/v1",
+	} {
+		endpoint, err := dataminded_api.ParseEndpoint(raw)
+
+		assert.Nil(t, err, raw)
+		assert.Equal(t, raw, endpoint.String())
+	}
+}
+
+func TestParseInvalidEndpoint(t *testing.T) {
+	tests := []struct {
+		raw     string
+		message string
+	}{
+		{raw: "localhost:3000", message: "unsupported scheme \"localhost\""},
+		{raw: "/dataminded/v1", message: "has no scheme"},
+		{raw: "ftp://localhost", message: "unsupported scheme \"ftp\""},
+		{raw: "http://", message: "has no host"},
+		{raw: "http://localhost:0", message: "between 1 and 65535"},
+		{raw: "https://localhost:65536", message: "between 1 and 65535"},
+		{raw: "unix://dataminded.sock", message: "absolute path of the socket"},
+		{raw: "unix:///run/dataminded.sock?base_path=dataminded/v1", message: "expected an absolute path"},
+		{raw: "http://local host", message: "parsing endpoint"},
+	}
+
+	for _, test := range tests {
+		_, err := dataminded_api.ParseEndpoint(test.raw)
+
+		assert.ErrorContains(t, err, test.message, test.raw)
+	}
+}
+
+func TestEndpointBasePath(t *testing.T) {
+	for _, basePath := range []string{"dataminded/v1", "dataminded/v1/"} {
+		connection, requestPath := pathConnection(t)
+		connection.Endpoint = connection.Endpoint.JoinPath(basePath)
+
+		_, err := dataminded_api.NewClient(connection).ReadUser(t.Context(), 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "/dataminded/v1/user/1", *requestPath)
+	}
+}
+
+func TestUnixSocketEndpoint(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "api.sock")
+	listener, err := net.Listen("unix", socket)
+	assert.Nil(t, err)
+
+	var requestPath string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.URL.Path
+		_, _ = w.Write([]byte(`{"id": 1, "name": "Jonathan"}`))
+	}))
+	server.Listener = listener
+	server.Start()
+	t.Cleanup(server.Close)
+
+	for raw, expectedPath := range map[string]string{
+		"unix://" + socket: "/user/1",
+		"unix://" + socket + "?base_path=/dataminded/v1/": "/dataminded/v1/user/1",
+	} {
+		endpoint, err := dataminded_api.ParseEndpoint(raw)
+		assert.Nil(t, err)
+
+		user, err := dataminded_api.NewClient(dataminded_api.Connection{Endpoint: endpoint}).ReadUser(t.Context(), 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "Jonathan", user.Name)
+		assert.Equal(t, expectedPath, requestPath)
+	}
+}
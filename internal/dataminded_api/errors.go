@@ -0,0 +1,45 @@
+package dataminded_api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrNotFound is matched by errors.Is when the requested record does not exist.
+	ErrNotFound = errors.New("record not found")
+
+	// ErrConflict is matched by errors.Is when the request clashes with an existing record.
+	ErrConflict = errors.New("record already exists")
+)
+
+// The API maps every database error to a 500, so these messages from diesel and
+// SQLite are the only way to tell a missing or duplicate record from a real failure.
+const (
+	errorRecordNotFound   = "Record not found"
+	errorUniqueConstraint = "UNIQUE constraint failed"
+)
+
+// APIError is returned for every response of the API with a non-2xx status code.
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s %s returned status %d: %s", e.Method, e.URL, e.StatusCode, e.Body)
+}
+
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound || strings.TrimSpace(e.Body) == errorRecordNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict || strings.Contains(e.Body, errorUniqueConstraint)
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package dataminded_api_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"terraform-provider-dataminded/internal/dataminded_api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stubClient returns a client for a stand-in API that answers every request with
+// the given status code and body.
+func stubClient(t *testing.T, statusCode int, body string) *dataminded_api.Client {
+	return dataminded_api.NewClient(stubConnection(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(body))
+	})))
+}
+
+func TestRecordNotFoundIsErrNotFound(t *testing.T) {
+	client := stubClient(t, http.StatusInternalServerError, "Record not found")
+
+	_, err := client.ReadUser(t.Context(), 1)
+
+	assert.ErrorIs(t, err, dataminded_api.ErrNotFound)
+	assert.NotErrorIs(t, err, dataminded_api.ErrConflict)
+}
+
+func TestStatusNotFoundIsErrNotFound(t *testing.T) {
+	client := stubClient(t, http.StatusNotFound, "")
+
+	_, err := client.ReadChapter(t.Context(), 1)
+
+	assert.ErrorIs(t, err, dataminded_api.ErrNotFound)
+}
+
+func TestUniqueConstraintIsErrConflict(t *testing.T) {
+	client := stubClient(t, http.StatusInternalServerError, "UNIQUE constraint failed: chapter_members.chapter_id, chapter_members.user_id")
+
+	err := client.CreateChapterMember(t.Context(), 1, 2, "Lead")
+
+	assert.ErrorIs(t, err, dataminded_api.ErrConflict)
+	assert.NotErrorIs(t, err, dataminded_api.ErrNotFound)
+}
+
+func TestServerErrorIsAPIError(t *testing.T) {
+	client := stubClient(t, http.StatusInternalServerError, "database is locked")
+
+	_, err := client.UpdateUser(t.Context(), 7, "name")
+
+	assert.NotErrorIs(t, err, dataminded_api.ErrNotFound)
+	assert.NotErrorIs(t, err, dataminded_api.ErrConflict)
+
+	var apiError *dataminded_api.APIError
+	assert.True(t, errors.As(err, &apiError))
+	assert.Equal(t, http.StatusInternalServerError, apiError.StatusCode)
+	assert.Equal(t, http.MethodPut, apiError.Method)
+	assert.Contains(t, apiError.URL, "/user/7")
+	assert.Equal(t, "database is locked", apiError.Body)
+}
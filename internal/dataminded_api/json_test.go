@@ -0,0 +1,89 @@
+package dataminded_api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"terraform-provider-dataminded/internal/dataminded_api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// hostileNames are names that break a request body built by string formatting.
+var hostileNames = []string{
+	`O"Brien`,
+	`back\slash`,
+	"new\nline",
+	"tab\tand\rcarriage return",
+	"control\x01\x1fcharacters",
+	"Ünïcødé 名前 🚀",
+	`x", "id": 5, "name": "injected`,
+	"",
+}
+
+// echoClient returns a client for a stand-in API that checks that every request body
+// is a JSON object and echoes its name back as a record with id 1.
+func echoClient(t *testing.T) *dataminded_api.Client {
+	return dataminded_api.NewClient(stubConnection(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		decoder := json.NewDecoder(r.Body)
+		decoder.DisallowUnknownFields()
+
+		var body struct {
+			Name string `json:"name"`
+		}
+		if err := decoder.Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": 1, "name": body.Name})
+	})))
+}
+
+func TestHostileNamesRoundTrip(t *testing.T) {
+	client := echoClient(t)
+
+	for _, name := range hostileNames {
+		user, err := client.CreateUser(t.Context(), name)
+		assert.Nil(t, err, name)
+		assert.Equal(t, dataminded_api.User{Id: 1, Name: name}, user)
+
+		user, err = client.UpdateUser(t.Context(), 1, name)
+		assert.Nil(t, err, name)
+		assert.Equal(t, name, user.Name)
+
+		chapter, err := client.CreateChapter(t.Context(), name)
+		assert.Nil(t, err, name)
+		assert.Equal(t, dataminded_api.Chapter{Id: 1, Name: name}, chapter)
+
+		chapter, err = client.UpdateChapter(t.Context(), 1, name)
+		assert.Nil(t, err, name)
+		assert.Equal(t, name, chapter.Name)
+	}
+}
+
+func TestChapterMemberRequestBody(t *testing.T) {
+	var body map[string]any
+	client := dataminded_api.NewClient(stubConnection(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		_, _ = w.Write([]byte(`{"chapter_id": 2, "user_id": 3, "role": "Lead"}`))
+	})))
+
+	err := client.CreateChapterMember(t.Context(), 2, 3, `Lead", "chapter_id": "9`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{"role": `Lead", "chapter_id": "9`}, body)
+}
+
+func TestInvalidJSONResponse(t *testing.T) {
+	client := stubClient(t, http.StatusOK, "not json")
+
+	_, err := client.ReadUser(t.Context(), 1)
+
+	assert.ErrorContains(t, err, "decoding response of GET /user/1")
+}
@@ -0,0 +1,94 @@
+package dataminded_api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxCandidates bounds the names listed by a NameLookupError, so that a typo in a
+// large organisation does not produce a wall of text.
+const maxCandidates = 10
+
+// NameLookupError is returned when a name does not match exactly one record.
+type NameLookupError struct {
+	// Kind is "user" or "chapter".
+	Kind string
+	Name string
+	// Matches are the records with the name, as "name (id N)". Empty when none has it.
+	Matches []string
+	// Candidates are the names of all records of the kind, when none has the name.
+	Candidates []string
+}
+
+func (e *NameLookupError) Error() string {
+	if len(e.Matches) > 0 {
+		return fmt.Sprintf("%d %ss are named %q, use the id of one of them instead: %s",
+			len(e.Matches), e.Kind, e.Name, strings.Join(e.Matches, ", "))
+	}
+
+	if len(e.Candidates) == 0 {
+		return fmt.Sprintf("no %s is named %q, and there are no %ss at all", e.Kind, e.Name, e.Kind)
+	}
+
+	candidates := e.Candidates
+	more := ""
+	if len(candidates) > maxCandidates {
+		more = fmt.Sprintf(" and %d more", len(candidates)-maxCandidates)
+		candidates = candidates[:maxCandidates]
+	}
+
+	return fmt.Sprintf("no %s is named %q, the %ss are named: %s%s",
+		e.Kind, e.Name, e.Kind, strings.Join(candidates, ", "), more)
+}
+
+// UserIdByName returns the id of the only user called name, or a *NameLookupError.
+func (c *Client) UserIdByName(ctx context.Context, name string) (int, error) {
+	users, err := c.ListUsers(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return idByName("user", name, users, func(user User) (int, string) { return user.Id, user.Name })
+}
+
+// ChapterIdByName returns the id of the only chapter called name, or a *NameLookupError.
+func (c *Client) ChapterIdByName(ctx context.Context, name string) (int, error) {
+	chapters, err := c.ListChapters(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return idByName("chapter", name, chapters, func(chapter Chapter) (int, string) { return chapter.Id, chapter.Name })
+}
+
+func idByName[T any](kind string, name string, records []T, key func(T) (int, string)) (int, error) {
+	var ids []int
+	var names []string
+
+	for _, record := range records {
+		id, recordName := key(record)
+		if recordName == name {
+			ids = append(ids, id)
+		}
+		names = append(names, recordName)
+	}
+
+	if len(ids) == 1 {
+		return ids[0], nil
+	}
+
+	lookupError := &NameLookupError{Kind: kind, Name: name}
+	if len(ids) > 1 {
+		sort.Ints(ids)
+		for _, id := range ids {
+			lookupError.Matches = append(lookupError.Matches, fmt.Sprintf("%s (id %d)", name, id))
+		}
+	} else {
+		sort.Strings(names)
+		lookupError.Candidates = names
+	}
+
+	return 0, lookupError
+}
@@ -0,0 +1,71 @@
+package dataminded_api_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"terraform-provider-dataminded/internal/dataminded_api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserIdByName(t *testing.T) {
+	client := stubClient(t, http.StatusOK, `[{"id": 1, "name": "Jonathan"}, {"id": 2, "name": "Jelle"}]`)
+
+	id, err := client.UserIdByName(t.Context(), "Jelle")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, id)
+}
+
+func TestChapterIdByName(t *testing.T) {
+	client := stubClient(t, http.StatusOK, `[{"id": 1, "name": "platform"}, {"id": 2, "name": "data"}]`)
+
+	id, err := client.ChapterIdByName(t.Context(), "platform")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, id)
+}
+
+func TestUnknownName(t *testing.T) {
+	client := stubClient(t, http.StatusOK, `[{"id": 1, "name": "Jonathan"}, {"id": 2, "name": "Jelle"}]`)
+
+	_, err := client.UserIdByName(t.Context(), "jelle")
+
+	var lookupError *dataminded_api.NameLookupError
+	assert.ErrorAs(t, err, &lookupError)
+	assert.Equal(t, []string{"Jelle", "Jonathan"}, lookupError.Candidates)
+	assert.EqualError(t, err, `no user is named "jelle", the users are named: Jelle, Jonathan`)
+}
+
+func TestUnknownNameWithManyCandidates(t *testing.T) {
+	var chapters []string
+	for i := range 12 {
+		chapters = append(chapters, fmt.Sprintf(`{"id": %d, "name": "chapter%02d"}`, i, i))
+	}
+	client := stubClient(t, http.StatusOK, "["+strings.Join(chapters, ",")+"]")
+
+	_, err := client.ChapterIdByName(t.Context(), "platform")
+
+	assert.ErrorContains(t, err, "chapter09 and 2 more")
+}
+
+func TestUnknownNameWithoutCandidates(t *testing.T) {
+	client := stubClient(t, http.StatusOK, `[]`)
+
+	_, err := client.ChapterIdByName(t.Context(), "platform")
+
+	assert.EqualError(t, err, `no chapter is named "platform", and there are no chapters at all`)
+}
+
+func TestAmbiguousName(t *testing.T) {
+	client := stubClient(t, http.StatusOK, `[{"id": 7, "name": "Jonathan"}, {"id": 3, "name": "Jonathan"}, {"id": 5, "name": "Jelle"}]`)
+
+	_, err := client.UserIdByName(t.Context(), "Jonathan")
+
+	var lookupError *dataminded_api.NameLookupError
+	assert.ErrorAs(t, err, &lookupError)
+	assert.EqualError(t, err, `2 users are named "Jonathan", use the id of one of them instead: Jonathan (id 3), Jonathan (id 7)`)
+}
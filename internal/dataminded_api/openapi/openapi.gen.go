@@ -0,0 +1,189 @@
+// Code generated by openapigen from api.json. DO NOT EDIT.
+
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Title is the title of the API the operations were generated from.
+const Title = "Dataminded example API"
+
+// Version is the version of the API the operations were generated from.
+const Version = "0.1.0"
+
+type Chapter struct {
+	Id   int32  `json:"id"`
+	Name string `json:"name"`
+}
+
+type ChapterMember struct {
+	ChapterId int32        `json:"chapter_id"`
+	Role      *ChapterRole `json:"role,omitempty"`
+	UserId    int32        `json:"user_id"`
+}
+
+type ChapterRole string
+
+const (
+	ChapterRoleContributor ChapterRole = "Contributor"
+	ChapterRoleLead        ChapterRole = "Lead"
+)
+
+type NewChapter struct {
+	Name string `json:"name"`
+}
+
+type NewChapterMember struct {
+	Role *ChapterRole `json:"role,omitempty"`
+}
+
+type NewUser struct {
+	Name string `json:"name"`
+}
+
+type User struct {
+	Id   int32  `json:"id"`
+	Name string `json:"name"`
+}
+
+// OperationIds lists the id of every operation of the API.
+var OperationIds = []string{
+	"createChapter",
+	"createChapterMember",
+	"createUser",
+	"deleteChapter",
+	"deleteChapterMember",
+	"deleteUser",
+	"getChapter",
+	"getChapterMember",
+	"getUser",
+	"listChapterMembers",
+	"listChapterMembersInChapter",
+	"listChapters",
+	"listUsers",
+	"updateChapter",
+	"updateChapterMember",
+	"updateUser",
+}
+
+// Operations calls the operations of the API through Do, which sends request, if not
+// nil, as the JSON body to the given path and decodes the JSON response into response,
+// if not nil.
+type Operations struct {
+	Do func(ctx context.Context, method string, path string, request any, response any) error
+}
+
+// CreateChapter calls createChapter (POST /chapter): Create a new chapter.
+func (o Operations) CreateChapter(ctx context.Context, body NewChapter) (Chapter, error) {
+	var response Chapter
+	err := o.Do(ctx, http.MethodPost, "/chapter", body, &response)
+	return response, err
+}
+
+// CreateChapterMember calls createChapterMember (POST /chapter/{id}/member/{user_id}): Create a new chapter member.
+func (o Operations) CreateChapterMember(ctx context.Context, id int32, userId int32, body NewChapterMember) (ChapterMember, error) {
+	var response ChapterMember
+	err := o.Do(ctx, http.MethodPost, fmt.Sprintf("/chapter/%v/member/%v", id, userId), body, &response)
+	return response, err
+}
+
+// CreateUser calls createUser (POST /user): Create a new user.
+func (o Operations) CreateUser(ctx context.Context, body NewUser) (User, error) {
+	var response User
+	err := o.Do(ctx, http.MethodPost, "/user", body, &response)
+	return response, err
+}
+
+// DeleteChapter calls deleteChapter (DELETE /chapter/{id}): Delete a chapter by ID.
+func (o Operations) DeleteChapter(ctx context.Context, id int32) (Chapter, error) {
+	var response Chapter
+	err := o.Do(ctx, http.MethodDelete, fmt.Sprintf("/chapter/%v", id), nil, &response)
+	return response, err
+}
+
+// DeleteChapterMember calls deleteChapterMember (DELETE /chapter/{id}/member/{user_id}): Delete a chapter member by ID.
+func (o Operations) DeleteChapterMember(ctx context.Context, id int32, userId int32) (ChapterMember, error) {
+	var response ChapterMember
+	err := o.Do(ctx, http.MethodDelete, fmt.Sprintf("/chapter/%v/member/%v", id, userId), nil, &response)
+	return response, err
+}
+
+// DeleteUser calls deleteUser (DELETE /user/{id}): Delete a user by ID.
+func (o Operations) DeleteUser(ctx context.Context, id int32) (User, error) {
+	var response User
+	err := o.Do(ctx, http.MethodDelete, fmt.Sprintf("/user/%v", id), nil, &response)
+	return response, err
+}
+
+// GetChapter calls getChapter (GET /chapter/{id}): Get a chapter by ID.
+func (o Operations) GetChapter(ctx context.Context, id int32) (Chapter, error) {
+	var response Chapter
+	err := o.Do(ctx, http.MethodGet, fmt.Sprintf("/chapter/%v", id), nil, &response)
+	return response, err
+}
+
+// GetChapterMember calls getChapterMember (GET /chapter/{id}/member/{user_id}): Get a chapter member by ID.
+func (o Operations) GetChapterMember(ctx context.Context, id int32, userId int32) (ChapterMember, error) {
+	var response ChapterMember
+	err := o.Do(ctx, http.MethodGet, fmt.Sprintf("/chapter/%v/member/%v", id, userId), nil, &response)
+	return response, err
+}
+
+// GetUser calls getUser (GET /user/{id}): Get a user by ID.
+func (o Operations) GetUser(ctx context.Context, id int32) (User, error) {
+	var response User
+	err := o.Do(ctx, http.MethodGet, fmt.Sprintf("/user/%v", id), nil, &response)
+	return response, err
+}
+
+// ListChapterMembers calls listChapterMembers (GET /chapter/member/): List all chapter members.
+func (o Operations) ListChapterMembers(ctx context.Context) ([]ChapterMember, error) {
+	var response []ChapterMember
+	err := o.Do(ctx, http.MethodGet, "/chapter/member/", nil, &response)
+	return response, err
+}
+
+// ListChapterMembersInChapter calls listChapterMembersInChapter (GET /chapter/{id}/member/): List chapter members for a chapter.
+func (o Operations) ListChapterMembersInChapter(ctx context.Context, id int32) ([]ChapterMember, error) {
+	var response []ChapterMember
+	err := o.Do(ctx, http.MethodGet, fmt.Sprintf("/chapter/%v/member/", id), nil, &response)
+	return response, err
+}
+
+// ListChapters calls listChapters (GET /chapter): List chapters.
+func (o Operations) ListChapters(ctx context.Context) ([]Chapter, error) {
+	var response []Chapter
+	err := o.Do(ctx, http.MethodGet, "/chapter", nil, &response)
+	return response, err
+}
+
+// ListUsers calls listUsers (GET /user): List users.
+func (o Operations) ListUsers(ctx context.Context) ([]User, error) {
+	var response []User
+	err := o.Do(ctx, http.MethodGet, "/user", nil, &response)
+	return response, err
+}
+
+// UpdateChapter calls updateChapter (PUT /chapter/{id}): Update a chapter by ID.
+func (o Operations) UpdateChapter(ctx context.Context, id int32, body NewChapter) (Chapter, error) {
+	var response Chapter
+	err := o.Do(ctx, http.MethodPut, fmt.Sprintf("/chapter/%v", id), body, &response)
+	return response, err
+}
+
+// UpdateChapterMember calls updateChapterMember (PUT /chapter/{id}/member/{user_id}): Update a chapter member by ID.
+func (o Operations) UpdateChapterMember(ctx context.Context, id int32, userId int32, body NewChapterMember) (ChapterMember, error) {
+	var response ChapterMember
+	err := o.Do(ctx, http.MethodPut, fmt.Sprintf("/chapter/%v/member/%v", id, userId), body, &response)
+	return response, err
+}
+
+// UpdateUser calls updateUser (PUT /user/{id}): Update a user by ID.
+func (o Operations) UpdateUser(ctx context.Context, id int32, body NewUser) (User, error) {
+	var response User
+	err := o.Do(ctx, http.MethodPut, fmt.Sprintf("/user/%v", id), body, &response)
+	return response, err
+}
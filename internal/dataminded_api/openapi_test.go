@@ -0,0 +1,53 @@
+package dataminded_api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"terraform-provider-dataminded/internal/acceptance"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// operations returns "METHOD path operationId" for every operation of an OpenAPI document.
+func operations(t *testing.T, document []byte) []string {
+	var spec struct {
+		Paths map[string]map[string]struct {
+			OperationId string `json:"operationId"`
+		} `json:"paths"`
+	}
+	assert.Nil(t, json.Unmarshal(document, &spec))
+
+	var result []string
+	for path, item := range spec.Paths {
+		for method, operation := range item {
+			result = append(result, fmt.Sprintf("%s %s %s", method, path, operation.OperationId))
+		}
+	}
+	return result
+}
+
+func TestCheckedInSpecMatchesAPI(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+
+	checkedIn, err := os.ReadFile("openapi/api.json")
+	assert.Nil(t, err)
+
+	request, err := http.NewRequestWithContext(t.Context(), http.MethodGet, data.Endpoint.JoinPath("api.json").String(), nil)
+	assert.Nil(t, err)
+
+	response, err := http.DefaultClient.Do(request)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer response.Body.Close()
+
+	var served json.RawMessage
+	assert.Nil(t, json.NewDecoder(response.Body).Decode(&served))
+
+	assert.ElementsMatch(t, operations(t, checkedIn), operations(t, served),
+		"openapi/api.json differs from the API, refresh it and run 'go generate ./...'")
+}
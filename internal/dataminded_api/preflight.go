@@ -0,0 +1,80 @@
+package dataminded_api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"terraform-provider-dataminded/internal/dataminded_api/openapi"
+)
+
+// ErrIncompatibleAPI is matched by errors.Is when the API is not the one the client
+// was generated from.
+var ErrIncompatibleAPI = errors.New("incompatible API")
+
+// Preflight checks that the API is reachable and that the OpenAPI document it serves
+// matches the title and version the client was generated from and has every operation
+// the client calls. An incompatible API is reported with ErrIncompatibleAPI.
+func (c *Client) Preflight(ctx context.Context) error {
+	var document struct {
+		Info struct {
+			Title   string `json:"title"`
+			Version string `json:"version"`
+		} `json:"info"`
+		// Path items hold parameters and servers next to the operations, so the
+		// operations are decoded one by one
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+
+	if err := c.doJSON(ctx, http.MethodGet, "/api.json", nil, &document); err != nil {
+		return err
+	}
+
+	if document.Info.Title != openapi.Title {
+		return fmt.Errorf("%w: expected %q, got %q", ErrIncompatibleAPI, openapi.Title, document.Info.Title)
+	}
+
+	if !compatibleVersion(openapi.Version, document.Info.Version) {
+		return fmt.Errorf("%w: expected version %s or a compatible one, got %s", ErrIncompatibleAPI, openapi.Version, document.Info.Version)
+	}
+
+	served := map[string]bool{}
+	for _, item := range document.Paths {
+		for _, raw := range item {
+			var operation struct {
+				OperationId string `json:"operationId"`
+			}
+			if json.Unmarshal(raw, &operation) == nil {
+				served[operation.OperationId] = true
+			}
+		}
+	}
+
+	var missing []string
+	for _, operationId := range openapi.OperationIds {
+		if !served[operationId] {
+			missing = append(missing, operationId)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: missing operations %s", ErrIncompatibleAPI, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// compatibleVersion follows semantic versioning: the major versions must match and,
+// before 1.0.0, the minor versions too.
+func compatibleVersion(expected string, actual string) bool {
+	expectedParts := strings.SplitN(expected, ".", 3)
+	actualParts := strings.SplitN(actual, ".", 3)
+	if len(expectedParts) < 2 || len(actualParts) < 2 || expectedParts[0] != actualParts[0] {
+		return false
+	}
+
+	return expectedParts[0] != "0" || expectedParts[1] == actualParts[1]
+}
@@ -0,0 +1,90 @@
+package dataminded_api_test
+
+import (
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"terraform-provider-dataminded/internal/acceptance"
+	"terraform-provider-dataminded/internal/dataminded_api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// documentClient returns a client for a stand-in API that serves the checked-in
+// OpenAPI document, with from replaced by to.
+func documentClient(t *testing.T, from string, to string) *dataminded_api.Client {
+	checkedIn, err := os.ReadFile("openapi/api.json")
+	assert.Nil(t, err)
+
+	served := strings.Replace(string(checkedIn), from, to, 1)
+
+	return dataminded_api.NewClient(stubConnection(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api.json" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(served))
+	})))
+}
+
+func TestPreflight(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	client := dataminded_api.NewClient(dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	})
+
+	assert.Nil(t, client.Preflight(t.Context()))
+}
+
+func TestPreflightCompatibleVersion(t *testing.T) {
+	client := documentClient(t, `"version": "0.1.0"`, `"version": "0.1.7"`)
+
+	assert.Nil(t, client.Preflight(t.Context()))
+}
+
+func TestPreflightIncompatibleAPI(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		message string
+	}{
+		{
+			name:    "other title",
+			from:    `"title": "Dataminded example API"`,
+			to:      `"title": "Petstore"`,
+			message: `expected "Dataminded example API", got "Petstore"`,
+		},
+		{
+			name:    "breaking version",
+			from:    `"version": "0.1.0"`,
+			to:      `"version": "0.2.0"`,
+			message: "got 0.2.0",
+		},
+		{
+			name:    "missing operation",
+			from:    `"operationId": "listChapterMembers"`,
+			to:      `"operationId": "listAllMembers"`,
+			message: "missing operations listChapterMembers",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := documentClient(t, test.from, test.to).Preflight(t.Context())
+
+			assert.ErrorIs(t, err, dataminded_api.ErrIncompatibleAPI)
+			assert.ErrorContains(t, err, test.message)
+		})
+	}
+}
+
+func TestPreflightWithoutDocument(t *testing.T) {
+	client := stubClient(t, http.StatusNotFound, "")
+
+	err := client.Preflight(t.Context())
+
+	assert.ErrorIs(t, err, dataminded_api.ErrNotFound)
+}
@@ -0,0 +1,144 @@
+package dataminded_api
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RetryPolicy controls how often and how patiently a failed request is retried.
+// The zero value disables retries.
+type RetryPolicy struct {
+	MaxRetries int
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	MinBackoff: 500 * time.Millisecond,
+	MaxBackoff: 10 * time.Second,
+}
+
+// Messages of the API for database errors where the request was rolled back, which
+// makes them safe to retry for any method. The API runs SQLite behind a pool of a
+// single connection, so these are common when many resources are applied in parallel.
+var transientErrors = []string{
+	"database is locked",
+	"timed out waiting for connection",
+}
+
+// backoff returns the delay before the given retry (starting at 0): exponential in the
+// attempt, capped at MaxBackoff, with jitter so parallel requests don't retry in lockstep.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.MinBackoff
+	for i := 0; i < attempt && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+
+	if delay <= 0 {
+		return 0
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// retryable reports whether a request that failed with err may be sent again.
+// GET, PUT and DELETE are idempotent and are retried on any server or connection error.
+// POST creates records, so it is only retried when the API cannot have applied it.
+// Other errors, such as an untrusted certificate, fail the same way on every attempt.
+func retryable(method string, err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	idempotent := method != http.MethodPost
+
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		if !connectionError(err) {
+			return false
+		}
+		var opError *net.OpError
+		refused := errors.As(err, &opError) && opError.Op == "dial"
+		return idempotent || refused
+	}
+
+	switch {
+	case apiError.StatusCode == http.StatusTooManyRequests, apiError.StatusCode == http.StatusServiceUnavailable:
+		return true
+	case apiError.StatusCode < 500:
+		return false
+	case errors.Is(apiError, ErrNotFound), errors.Is(apiError, ErrConflict):
+		return false
+	}
+
+	for _, message := range transientErrors {
+		if strings.Contains(apiError.Body, message) {
+			return true
+		}
+	}
+
+	return idempotent
+}
+
+// connectionError reports whether err is a failure to reach the API or a connection
+// that broke off, as opposed to a certificate the client does not trust.
+func connectionError(err error) bool {
+	var certificateError *tls.CertificateVerificationError
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostnameError x509.HostnameError
+	var invalidCertificate x509.CertificateInvalidError
+	if errors.As(err, &certificateError) || errors.As(err, &unknownAuthority) ||
+		errors.As(err, &hostnameError) || errors.As(err, &invalidCertificate) {
+		return false
+	}
+
+	// The *url.Error returned by the HTTP client is itself a net.Error, so look past it
+	var urlError *url.Error
+	if errors.As(err, &urlError) {
+		err = urlError.Err
+	}
+
+	var netError net.Error
+	return errors.As(err, &netError) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// wait blocks for the backoff of the given retry, or until ctx is cancelled.
+func (p RetryPolicy) wait(ctx context.Context, method string, url string, attempt int, err error) error {
+	delay := p.backoff(attempt)
+
+	tflog.Warn(ctx, "Retrying request to the Dataminded API", map[string]interface{}{
+		"method":  method,
+		"url":     url,
+		"attempt": attempt + 1,
+		"backoff": delay.String(),
+		"error":   err.Error(),
+	})
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
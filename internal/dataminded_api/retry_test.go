@@ -0,0 +1,185 @@
+package dataminded_api_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"terraform-provider-dataminded/internal/dataminded_api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testRetryPolicy = dataminded_api.RetryPolicy{
+	MaxRetries: 3,
+	MinBackoff: time.Millisecond,
+	MaxBackoff: 5 * time.Millisecond,
+}
+
+// flakyClient returns a client for a stand-in API that fails the first `failures`
+// requests with the given status code and body, and counts all requests it receives.
+func flakyClient(t *testing.T, failures int32, statusCode int, body string) (*dataminded_api.Client, *atomic.Int32) {
+	var requests atomic.Int32
+
+	connection := stubConnection(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= failures {
+			w.WriteHeader(statusCode)
+			_, _ = w.Write([]byte(body))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": 1, "name": "Jonathan"}`))
+	}))
+	connection.Retry = testRetryPolicy
+
+	return dataminded_api.NewClient(connection), &requests
+}
+
+func TestRetryGetOnServerError(t *testing.T) {
+	client, requests := flakyClient(t, 2, http.StatusInternalServerError, "timed out waiting for connection")
+
+	user, err := client.ReadUser(t.Context(), 1)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Jonathan", user.Name)
+	assert.Equal(t, int32(3), requests.Load())
+}
+
+func TestRetryGivesUpAfterMaxRetries(t *testing.T) {
+	client, requests := flakyClient(t, 10, http.StatusBadGateway, "")
+
+	_, err := client.ReadUser(t.Context(), 1)
+
+	var apiError *dataminded_api.APIError
+	assert.ErrorAs(t, err, &apiError)
+	assert.Equal(t, http.StatusBadGateway, apiError.StatusCode)
+	assert.Equal(t, int32(testRetryPolicy.MaxRetries+1), requests.Load())
+}
+
+func TestRetryPostOnDatabaseLocked(t *testing.T) {
+	client, requests := flakyClient(t, 1, http.StatusInternalServerError, "database is locked")
+
+	_, err := client.CreateUser(t.Context(), "Jonathan")
+
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestRetryPostOnTooManyRequests(t *testing.T) {
+	client, requests := flakyClient(t, 1, http.StatusTooManyRequests, "")
+
+	_, err := client.CreateChapter(t.Context(), "platform")
+
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestNoRetryPostOnUnknownServerError(t *testing.T) {
+	client, requests := flakyClient(t, 1, http.StatusInternalServerError, "something went wrong")
+
+	_, err := client.CreateUser(t.Context(), "Jonathan")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestNoRetryOnNotFound(t *testing.T) {
+	client, requests := flakyClient(t, 1, http.StatusInternalServerError, "Record not found")
+
+	_, err := client.ReadUser(t.Context(), 1)
+
+	assert.ErrorIs(t, err, dataminded_api.ErrNotFound)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestNoRetryOnClientError(t *testing.T) {
+	client, requests := flakyClient(t, 1, http.StatusUnprocessableEntity, "unknown variant `lead`")
+
+	err := client.UpdateChapterMember(t.Context(), 1, 1, "lead")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestRetryPostOnConnectionRefused(t *testing.T) {
+	// Reserve a free port and release it again, so that nothing is listening on it.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	assert.Nil(t, err)
+	assert.Nil(t, listener.Close())
+
+	start := time.Now()
+	client := dataminded_api.NewClient(dataminded_api.Connection{
+		Endpoint: &url.URL{Scheme: "http", Host: "127.0.0.1:" + port},
+		Retry:    dataminded_api.RetryPolicy{MaxRetries: 2, MinBackoff: 20 * time.Millisecond, MaxBackoff: 20 * time.Millisecond},
+	})
+
+	_, err = client.CreateUser(t.Context(), "Jonathan")
+
+	assert.ErrorContains(t, err, "127.0.0.1:"+port)
+	// Two retries, each waiting at least half of the backoff.
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+}
+
+func TestRetryGetOnConnectionReset(t *testing.T) {
+	var requests atomic.Int32
+	connection := stubConnection(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			// Drop the connection without writing a response
+			conn, _, err := http.NewResponseController(w).Hijack()
+			if err == nil {
+				_ = conn.Close()
+			}
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": 1, "name": "Jonathan"}`))
+	}))
+	connection.Retry = testRetryPolicy
+
+	user, err := dataminded_api.NewClient(connection).ReadUser(t.Context(), 1)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Jonathan", user.Name)
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestNoRetryOnUntrustedCertificate(t *testing.T) {
+	var connections atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	serverUrl, err := url.Parse(server.URL)
+	assert.Nil(t, err)
+	client := dataminded_api.NewClient(dataminded_api.Connection{Endpoint: serverUrl, Retry: testRetryPolicy})
+
+	_, err = client.ReadUser(t.Context(), 1)
+
+	assert.ErrorContains(t, err, "certificate")
+	assert.Equal(t, int32(1), connections.Load())
+}
+
+func TestRetryStopsWhenContextIsCancelled(t *testing.T) {
+	connection := stubConnection(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	connection.Retry = dataminded_api.RetryPolicy{MaxRetries: 10, MinBackoff: time.Hour, MaxBackoff: time.Hour}
+	client := dataminded_api.NewClient(connection)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.ReadUser(ctx, 1)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
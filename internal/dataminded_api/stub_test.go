@@ -0,0 +1,26 @@
+package dataminded_api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"terraform-provider-dataminded/internal/dataminded_api"
+)
+
+// stubConnection starts a stand-in for the API served by handler and returns the
+// connection to reach it. The server is stopped when the test ends.
+func stubConnection(t *testing.T, handler http.Handler) dataminded_api.Connection {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	serverUrl, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dataminded_api.Connection{
+		Endpoint: serverUrl,
+	}
+}
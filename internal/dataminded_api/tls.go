@@ -0,0 +1,69 @@
+package dataminded_api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// TLSOptions describe how to verify the API and how to authenticate to it over HTTPS.
+type TLSOptions struct {
+	// CACertPEM or CACertFile hold the CA bundle that signed the certificate of the API.
+	// The system roots are used when neither is set.
+	CACertPEM  string
+	CACertFile string
+
+	// ClientCertPEM and ClientKeyPEM are presented to APIs that require mutual TLS.
+	ClientCertPEM string
+	ClientKeyPEM  string
+
+	InsecureSkipVerify bool
+}
+
+// Config returns the tls.Config for the options, or nil when the defaults of Go suffice.
+func (o TLSOptions) Config() (*tls.Config, error) {
+	if o == (TLSOptions{}) {
+		return nil, nil
+	}
+
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: o.InsecureSkipVerify,
+	}
+
+	caCertPEM := []byte(o.CACertPEM)
+	if o.CACertFile != "" {
+		if o.CACertPEM != "" {
+			return nil, errors.New("only one of the CA certificate PEM and the CA certificate file can be set")
+		}
+
+		var err error
+		caCertPEM, err = os.ReadFile(o.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA certificate file: %w", err)
+		}
+	}
+
+	if len(caCertPEM) > 0 {
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(caCertPEM) {
+			return nil, errors.New("no valid PEM certificate found in the CA certificate")
+		}
+	}
+
+	if (o.ClientCertPEM == "") != (o.ClientKeyPEM == "") {
+		return nil, errors.New("the client certificate and the client key must be set together")
+	}
+
+	if o.ClientCertPEM != "" {
+		certificate, err := tls.X509KeyPair([]byte(o.ClientCertPEM), []byte(o.ClientKeyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	return config, nil
+}
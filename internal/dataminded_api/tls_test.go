@@ -0,0 +1,133 @@
+package dataminded_api_test
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"terraform-provider-dataminded/internal/dataminded_api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// tlsConnection starts a stand-in for the API over HTTPS and returns the connection
+// to reach it, together with the PEM of its self-signed certificate.
+func tlsConnection(t *testing.T) (dataminded_api.Connection, string) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": 1, "name": "Jonathan"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	serverUrl, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	connection := dataminded_api.Connection{
+		Endpoint: serverUrl,
+	}
+
+	return connection, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+}
+
+func TestDefaultTLSOptions(t *testing.T) {
+	config, err := dataminded_api.TLSOptions{}.Config()
+
+	assert.Nil(t, err)
+	assert.Nil(t, config)
+}
+
+func TestUnknownCertificateAuthority(t *testing.T) {
+	connection, _ := tlsConnection(t)
+
+	_, err := dataminded_api.NewClient(connection).ReadUser(t.Context(), 1)
+
+	assert.ErrorContains(t, err, "certificate")
+}
+
+func TestCACertPEM(t *testing.T) {
+	connection, caCertPEM := tlsConnection(t)
+
+	config, err := dataminded_api.TLSOptions{CACertPEM: caCertPEM}.Config()
+	assert.Nil(t, err)
+	connection.TLSConfig = config
+
+	user, err := dataminded_api.NewClient(connection).ReadUser(t.Context(), 1)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Jonathan", user.Name)
+}
+
+func TestCACertFile(t *testing.T) {
+	connection, caCertPEM := tlsConnection(t)
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	assert.Nil(t, os.WriteFile(caCertFile, []byte(caCertPEM), 0o600))
+
+	config, err := dataminded_api.TLSOptions{CACertFile: caCertFile}.Config()
+	assert.Nil(t, err)
+	connection.TLSConfig = config
+
+	user, err := dataminded_api.NewClient(connection).ReadUser(t.Context(), 1)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Jonathan", user.Name)
+}
+
+func TestInsecureSkipVerify(t *testing.T) {
+	connection, _ := tlsConnection(t)
+
+	config, err := dataminded_api.TLSOptions{InsecureSkipVerify: true}.Config()
+	assert.Nil(t, err)
+	connection.TLSConfig = config
+
+	user, err := dataminded_api.NewClient(connection).ReadUser(t.Context(), 1)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Jonathan", user.Name)
+}
+
+func TestInvalidTLSOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		options dataminded_api.TLSOptions
+		message string
+	}{
+		{
+			name:    "CA certificate without PEM",
+			options: dataminded_api.TLSOptions{CACertPEM: "not a certificate"},
+			message: "no valid PEM certificate",
+		},
+		{
+			name:    "CA certificate PEM and file",
+			options: dataminded_api.TLSOptions{CACertPEM: "a", CACertFile: "b"},
+			message: "only one of",
+		},
+		{
+			name:    "missing CA certificate file",
+			options: dataminded_api.TLSOptions{CACertFile: filepath.Join(t.TempDir(), "missing.pem")},
+			message: "reading CA certificate file",
+		},
+		{
+			name:    "client certificate without key",
+			options: dataminded_api.TLSOptions{ClientCertPEM: "a"},
+			message: "must be set together",
+		},
+		{
+			name:    "invalid client certificate",
+			options: dataminded_api.TLSOptions{ClientCertPEM: "a", ClientKeyPEM: "b"},
+			message: "loading client certificate",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.options.Config()
+
+			assert.ErrorContains(t, err, test.message)
+		})
+	}
+}
@@ -1,11 +1,9 @@
 package dataminded_api
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
+	"context"
+
+	"terraform-provider-dataminded/internal/dataminded_api/openapi"
 )
 
 type User struct {
@@ -13,138 +11,55 @@ type User struct {
 	Name string
 }
 
-func ListUsers(connection Connection) ([]User, error) {
-	response, err := http.Get(fmt.Sprintf("%s/user", baseUrl(connection)))
-	if err != nil {
-		return nil, err
+func userFromApi(user openapi.User) User {
+	return User{
+		Id:   int(user.Id),
+		Name: user.Name,
 	}
+}
 
-	responseData, err := io.ReadAll(response.Body)
+func (c *Client) ListUsers(ctx context.Context) ([]User, error) {
+	users, err := c.api.ListUsers(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var users []User
-	err = json.Unmarshal(responseData, &users)
-	if err != nil {
-		return nil, err
+	result := make([]User, 0, len(users))
+	for _, user := range users {
+		result = append(result, userFromApi(user))
 	}
 
-	return users, nil
+	return result, nil
 }
 
-func CreateUser(connection Connection, name string) (User, error) {
-	body := []byte(fmt.Sprintf(`{
-		"name": "%s"
-	}`, name))
-
-	response, err := http.Post(
-		fmt.Sprintf("%s/user", baseUrl(connection)),
-		"application/json",
-		bytes.NewBuffer(body),
-	)
-
-	if err != nil {
-		return User{}, err
-	}
-
-	responseData, err := io.ReadAll(response.Body)
-	if err != nil {
-		return User{}, err
-	}
-
-	var user User
-	err = json.Unmarshal(responseData, &user)
+func (c *Client) CreateUser(ctx context.Context, name string) (User, error) {
+	user, err := c.api.CreateUser(ctx, openapi.NewUser{Name: name})
 	if err != nil {
 		return User{}, err
 	}
 
-	return user, nil
+	return userFromApi(user), nil
 }
 
-func ReadUser(connection Connection, id int) (User, error) {
-	response, err := http.Get(fmt.Sprintf("%s/user/%d", baseUrl(connection), id))
-	if err != nil {
-		return User{}, err
-	}
-
-	responseData, err := io.ReadAll(response.Body)
-	if err != nil {
-		return User{}, err
-	}
-
-	if string(responseData) == ERROR_USER_NOT_FOUND {
-		return User{
-			Id: -1,
-		}, nil
-	}
-
-	var user User
-	err = json.Unmarshal(responseData, &user)
+func (c *Client) ReadUser(ctx context.Context, id int) (User, error) {
+	user, err := c.api.GetUser(ctx, int32(id))
 	if err != nil {
 		return User{}, err
 	}
 
-	return user, nil
+	return userFromApi(user), nil
 }
 
-func UpdateUser(connection Connection, id int, name string) (User, error) {
-	body := []byte(fmt.Sprintf(`{
-		"name": "%s"
-	}`, name))
-
-	request, err := http.NewRequest(http.MethodPut,
-		fmt.Sprintf("%s/user/%d", baseUrl(connection), id),
-		bytes.NewBuffer(body),
-	)
-	request.Header.Set("Content-Type", "application/json")
-
-	if err != nil {
-		return User{}, err
-	}
-
-	response, err := (&http.Client{}).Do(request)
-	if err != nil {
-		return User{}, err
-	}
-
-	responseData, err := io.ReadAll(response.Body)
-	if err != nil {
-		return User{}, err
-	}
-
-	var user User
-	err = json.Unmarshal(responseData, &user)
+func (c *Client) UpdateUser(ctx context.Context, id int, name string) (User, error) {
+	user, err := c.api.UpdateUser(ctx, int32(id), openapi.NewUser{Name: name})
 	if err != nil {
 		return User{}, err
 	}
 
-	return user, nil
-}
-
-func DeleteUser(connection Connection, id int) error {
-
-	request, err := http.NewRequest(http.MethodDelete,
-		fmt.Sprintf("%s/user/%d", baseUrl(connection), id),
-		nil,
-	)
-
-	if err != nil {
-		return err
-	}
-
-	response, err := (&http.Client{}).Do(request)
-	if err != nil {
-		return err
-	}
-
-	if response.StatusCode != 200 {
-		return fmt.Errorf("non 200 status code when deleting user %d", id)
-	}
-
-	return nil
+	return userFromApi(user), nil
 }
 
-func UserExists(user User) bool {
-	return user.Id != -1
+func (c *Client) DeleteUser(ctx context.Context, id int) error {
+	_, err := c.api.DeleteUser(ctx, int32(id))
+	return err
 }
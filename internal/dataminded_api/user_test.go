@@ -12,12 +12,11 @@ import (
 
 func TestCreateUser(t *testing.T) {
 	data := acceptance.BuildTestData(t)
-	connection := dataminded_api.Connection{
-		Host: data.Host,
-		Port: data.Port,
-	}
+	client := dataminded_api.NewClient(dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	})
 
-	user, err := dataminded_api.CreateUser(connection, data.RandomString)
+	user, err := client.CreateUser(t.Context(), data.RandomString)
 
 	if err != nil {
 		t.Log(err)
@@ -26,7 +25,7 @@ func TestCreateUser(t *testing.T) {
 	assert.Equal(t, data.RandomString, user.Name)
 
 	// Test that the user is read correctly
-	user, err = dataminded_api.ReadUser(connection, user.Id)
+	user, err = client.ReadUser(t.Context(), user.Id)
 	if err != nil {
 		t.Log(err)
 	}
@@ -35,7 +34,7 @@ func TestCreateUser(t *testing.T) {
 
 	// Test that the user is in the list of users
 	var users []dataminded_api.User
-	users, err = dataminded_api.ListUsers(connection)
+	users, err = client.ListUsers(t.Context())
 	if err != nil {
 		t.Log(err)
 	}
@@ -53,29 +52,23 @@ func TestCreateUser(t *testing.T) {
 
 func TestReadNonExistentUser(t *testing.T) {
 	data := acceptance.BuildTestData(t)
-	connection := dataminded_api.Connection{
-		Host: data.Host,
-		Port: data.Port,
-	}
+	client := dataminded_api.NewClient(dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	})
 
 	t.Log(data.RandomInteger)
-	user, err := dataminded_api.ReadUser(connection, data.RandomInteger)
+	_, err := client.ReadUser(t.Context(), data.RandomInteger)
 
-	if err != nil {
-		t.Log(err)
-	}
-	assert.Nil(t, err)
-	assert.Equal(t, user.Id, -1)
+	assert.ErrorIs(t, err, dataminded_api.ErrNotFound)
 }
 
 func TestListUsers(t *testing.T) {
 	data := acceptance.BuildTestData(t)
-	connection := dataminded_api.Connection{
-		Host: data.Host,
-		Port: data.Port,
-	}
+	client := dataminded_api.NewClient(dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	})
 
-	_, err := dataminded_api.ListUsers(connection)
+	_, err := client.ListUsers(t.Context())
 
 	if err != nil {
 		t.Log(err)
@@ -85,15 +78,14 @@ func TestListUsers(t *testing.T) {
 
 func TestUpdateUser(t *testing.T) {
 	data := acceptance.BuildTestData(t)
-	connection := dataminded_api.Connection{
-		Host: data.Host,
-		Port: data.Port,
-	}
+	client := dataminded_api.NewClient(dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	})
 
 	originalName := data.RandomString
 	newName := fmt.Sprintf("%s-new", originalName)
 
-	user, err := dataminded_api.CreateUser(connection, originalName)
+	user, err := client.CreateUser(t.Context(), originalName)
 	originalId := user.Id
 
 	if err != nil {
@@ -102,7 +94,7 @@ func TestUpdateUser(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, originalName, user.Name)
 
-	user, err = dataminded_api.UpdateUser(connection, originalId, newName)
+	user, err = client.UpdateUser(t.Context(), originalId, newName)
 	if err != nil {
 		t.Log(err)
 	}
@@ -110,7 +102,7 @@ func TestUpdateUser(t *testing.T) {
 	assert.Equal(t, newName, user.Name)
 
 	// check that if we read the originalId we obtain the new name
-	user, err = dataminded_api.ReadUser(connection, originalId)
+	user, err = client.ReadUser(t.Context(), originalId)
 	if err != nil {
 		t.Log(err)
 	}
@@ -120,12 +112,11 @@ func TestUpdateUser(t *testing.T) {
 
 func TestDeleteUser(t *testing.T) {
 	data := acceptance.BuildTestData(t)
-	connection := dataminded_api.Connection{
-		Host: data.Host,
-		Port: data.Port,
-	}
+	client := dataminded_api.NewClient(dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	})
 
-	user, err := dataminded_api.CreateUser(connection, data.RandomString)
+	user, err := client.CreateUser(t.Context(), data.RandomString)
 
 	if err != nil {
 		t.Log(err)
@@ -133,18 +124,31 @@ func TestDeleteUser(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, data.RandomString, user.Name)
 
-	err = dataminded_api.DeleteUser(connection, user.Id)
+	err = client.DeleteUser(t.Context(), user.Id)
 	if err != nil {
 		t.Log(err)
 	}
 	assert.Nil(t, err)
 
 	// check that the user no longer exists
-	user, err = dataminded_api.ReadUser(connection, data.RandomInteger)
+	_, err = client.ReadUser(t.Context(), user.Id)
 
-	if err != nil {
-		t.Log(err)
+	assert.ErrorIs(t, err, dataminded_api.ErrNotFound)
+}
+
+func TestUserHostileNames(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	client := dataminded_api.NewClient(dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	})
+
+	for _, name := range hostileNames {
+		user, err := client.CreateUser(t.Context(), name)
+		assert.Nil(t, err, name)
+		assert.Equal(t, name, user.Name)
+
+		user, err = client.ReadUser(t.Context(), user.Id)
+		assert.Nil(t, err, name)
+		assert.Equal(t, name, user.Name)
 	}
-	assert.Nil(t, err)
-	assert.Equal(t, user.Id, -1)
 }
@@ -0,0 +1,33 @@
+// Command openapigen writes the Go code generated from an OpenAPI document.
+//
+//	go run ./internal/openapigen/cmd -spec api.json -out openapi.gen.go -package openapi
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"terraform-provider-dataminded/internal/openapigen"
+)
+
+func main() {
+	specPath := flag.String("spec", "", "path of the OpenAPI document")
+	outPath := flag.String("out", "", "path of the generated Go file")
+	packageName := flag.String("package", "openapi", "package of the generated Go file")
+	flag.Parse()
+
+	document, err := os.ReadFile(*specPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	source, err := openapigen.Generate(document, *packageName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*outPath, source, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,412 @@
+// Package openapigen generates typed Go models and operations from the OpenAPI
+// document the Dataminded API serves at /api.json.
+package openapigen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+type spec struct {
+	Info struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	} `json:"info"`
+	Paths      map[string]map[string]operation `json:"paths"`
+	Components struct {
+		Schemas map[string]schema `json:"schemas"`
+	} `json:"components"`
+}
+
+type operation struct {
+	OperationId string      `json:"operationId"`
+	Description string      `json:"description"`
+	Parameters  []parameter `json:"parameters"`
+	RequestBody *struct {
+		Content map[string]struct {
+			Schema schema `json:"schema"`
+		} `json:"content"`
+	} `json:"requestBody"`
+	Responses map[string]struct {
+		Content map[string]struct {
+			Schema schema `json:"schema"`
+		} `json:"content"`
+	} `json:"responses"`
+}
+
+type parameter struct {
+	Name   string `json:"name"`
+	In     string `json:"in"`
+	Schema schema `json:"schema"`
+}
+
+type schema struct {
+	Ref        string            `json:"$ref"`
+	Type       any               `json:"type"`
+	Format     string            `json:"format"`
+	Items      *schema           `json:"items"`
+	Enum       []string          `json:"enum"`
+	Properties map[string]schema `json:"properties"`
+	Required   []string          `json:"required"`
+	OneOf      []schema          `json:"oneOf"`
+}
+
+// methods lists the HTTP methods of a path item in the order they are generated.
+var methods = []string{"get", "put", "post", "delete", "patch"}
+
+// imports lists the packages generated code may use, with how it uses them.
+var imports = []struct {
+	path  string
+	usage string
+}{
+	{"context", "context.Context"},
+	{"fmt", "fmt.Sprintf("},
+	{"net/http", "http.Method"},
+	{"net/url", "url.PathEscape("},
+}
+
+var pathParameter = regexp.MustCompile(`\{([^}]+)\}`)
+
+// Generate returns the formatted source of a Go file in package packageName with a
+// type for every schema and a method on Operations for every operation in the
+// given OpenAPI document.
+func Generate(document []byte, packageName string) ([]byte, error) {
+	var api spec
+	if err := json.Unmarshal(document, &api); err != nil {
+		return nil, fmt.Errorf("parsing OpenAPI document: %w", err)
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Title is the title of the API the operations were generated from.\n")
+	fmt.Fprintf(&out, "const Title = %q\n\n", api.Info.Title)
+	fmt.Fprintf(&out, "// Version is the version of the API the operations were generated from.\n")
+	fmt.Fprintf(&out, "const Version = %q\n\n", api.Info.Version)
+
+	if err := writeSchemas(&out, api.Components.Schemas); err != nil {
+		return nil, err
+	}
+
+	if err := writeOperations(&out, api.Paths); err != nil {
+		return nil, err
+	}
+
+	var file bytes.Buffer
+	fmt.Fprintf(&file, "// Code generated by openapigen from api.json. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&file, "package %s\n\n", packageName)
+	fmt.Fprintf(&file, "import (\n")
+	for _, imported := range imports {
+		if bytes.Contains(out.Bytes(), []byte(imported.usage)) {
+			fmt.Fprintf(&file, "\t%q\n", imported.path)
+		}
+	}
+	fmt.Fprintf(&file, ")\n\n")
+	file.Write(out.Bytes())
+
+	source, err := format.Source(file.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+
+	return source, nil
+}
+
+func writeSchemas(out *bytes.Buffer, schemas map[string]schema) error {
+	for _, name := range sortedKeys(schemas) {
+		s := schemas[name]
+
+		if len(s.Enum) > 0 {
+			fmt.Fprintf(out, "type %s string\n\nconst (\n", name)
+			for _, value := range s.Enum {
+				fmt.Fprintf(out, "\t%s%s %s = %q\n", name, goName(value), name, value)
+			}
+			fmt.Fprintf(out, ")\n\n")
+			continue
+		}
+
+		if typeName(s) != "object" {
+			return fmt.Errorf("schema %s: only objects and string enums are supported", name)
+		}
+
+		fmt.Fprintf(out, "type %s struct {\n", name)
+		for _, property := range sortedKeys(s.Properties) {
+			required := slices.Contains(s.Required, property)
+
+			fieldType, err := goType(s.Properties[property])
+			if err != nil {
+				return fmt.Errorf("schema %s, property %s: %w", name, property, err)
+			}
+
+			tag := property
+			if !required {
+				tag += ",omitempty"
+				if !strings.HasPrefix(fieldType, "*") && !strings.HasPrefix(fieldType, "[]") {
+					fieldType = "*" + fieldType
+				}
+			}
+
+			fmt.Fprintf(out, "\t%s %s `json:%q`\n", goName(property), fieldType, tag)
+		}
+		fmt.Fprintf(out, "}\n\n")
+	}
+
+	return nil
+}
+
+func writeOperations(out *bytes.Buffer, paths map[string]map[string]operation) error {
+	type entry struct {
+		path      string
+		method    string
+		operation operation
+	}
+
+	var entries []entry
+	for path, item := range paths {
+		for _, method := range methods {
+			if op, ok := item[method]; ok {
+				entries = append(entries, entry{path, method, op})
+			}
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].operation.OperationId < entries[j].operation.OperationId
+	})
+
+	fmt.Fprintf(out, "// OperationIds lists the id of every operation of the API.\n")
+	fmt.Fprintf(out, "var OperationIds = []string{\n")
+	for _, e := range entries {
+		fmt.Fprintf(out, "\t%q,\n", e.operation.OperationId)
+	}
+	fmt.Fprintf(out, "}\n\n")
+
+	fmt.Fprintf(out, "// Operations calls the operations of the API through Do, which sends request, if not\n")
+	fmt.Fprintf(out, "// nil, as the JSON body to the given path and decodes the JSON response into response,\n")
+	fmt.Fprintf(out, "// if not nil.\n")
+	fmt.Fprintf(out, "type Operations struct {\n")
+	fmt.Fprintf(out, "\tDo func(ctx context.Context, method string, path string, request any, response any) error\n")
+	fmt.Fprintf(out, "}\n\n")
+
+	for _, e := range entries {
+		if err := writeOperation(out, e.path, e.method, e.operation); err != nil {
+			return fmt.Errorf("operation %s: %w", e.operation.OperationId, err)
+		}
+	}
+
+	return nil
+}
+
+func writeOperation(out *bytes.Buffer, path string, method string, op operation) error {
+	if op.OperationId == "" {
+		return fmt.Errorf("%s %s has no operationId", strings.ToUpper(method), path)
+	}
+
+	arguments := []string{"ctx context.Context"}
+	formatArguments := []string{}
+	types := map[string]string{}
+
+	for _, p := range op.Parameters {
+		if p.In != "path" {
+			return fmt.Errorf("parameter %s: only path parameters are supported", p.Name)
+		}
+
+		parameterType, err := goType(p.Schema)
+		if err != nil {
+			return fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+
+		types[p.Name] = parameterType
+		arguments = append(arguments, fmt.Sprintf("%s %s", argumentName(p.Name), parameterType))
+	}
+
+	pathFormat := pathParameter.ReplaceAllStringFunc(path, func(match string) string {
+		name := match[1 : len(match)-1]
+		if types[name] == "string" {
+			formatArguments = append(formatArguments, fmt.Sprintf("url.PathEscape(%s)", argumentName(name)))
+			return "%s"
+		}
+		formatArguments = append(formatArguments, argumentName(name))
+		return "%v"
+	})
+
+	for _, match := range pathParameter.FindAllStringSubmatch(path, -1) {
+		if _, ok := types[match[1]]; !ok {
+			return fmt.Errorf("path parameter %s is not declared", match[1])
+		}
+	}
+
+	request := "nil"
+	if op.RequestBody != nil {
+		content, ok := op.RequestBody.Content["application/json"]
+		if !ok {
+			return fmt.Errorf("only application/json request bodies are supported")
+		}
+
+		bodyType, err := goType(content.Schema)
+		if err != nil {
+			return fmt.Errorf("request body: %w", err)
+		}
+
+		arguments = append(arguments, "body "+bodyType)
+		request = "body"
+	}
+
+	responseType, err := successResponse(op)
+	if err != nil {
+		return err
+	}
+
+	pathExpression := fmt.Sprintf("%q", path)
+	if len(formatArguments) > 0 {
+		pathExpression = fmt.Sprintf("fmt.Sprintf(%q, %s)", pathFormat, strings.Join(formatArguments, ", "))
+	}
+
+	name := goName(op.OperationId)
+	methodConstant := fmt.Sprintf("http.Method%s%s", strings.ToUpper(method[:1]), method[1:])
+
+	fmt.Fprintf(out, "// %s calls %s (%s %s): %s.\n", name, op.OperationId, strings.ToUpper(method), path, strings.TrimSuffix(op.Description, "."))
+
+	if responseType == "" {
+		fmt.Fprintf(out, "func (o Operations) %s(%s) error {\n", name, strings.Join(arguments, ", "))
+		fmt.Fprintf(out, "\treturn o.Do(ctx, %s, %s, %s, nil)\n", methodConstant, pathExpression, request)
+		fmt.Fprintf(out, "}\n\n")
+		return nil
+	}
+
+	fmt.Fprintf(out, "func (o Operations) %s(%s) (%s, error) {\n", name, strings.Join(arguments, ", "), responseType)
+	fmt.Fprintf(out, "\tvar response %s\n", responseType)
+	fmt.Fprintf(out, "\terr := o.Do(ctx, %s, %s, %s, &response)\n", methodConstant, pathExpression, request)
+	fmt.Fprintf(out, "\treturn response, err\n")
+	fmt.Fprintf(out, "}\n\n")
+
+	return nil
+}
+
+// successResponse returns the Go type of the JSON body of the first 2xx response,
+// or "" if the operation returns no body.
+func successResponse(op operation) (string, error) {
+	for _, status := range sortedKeys(op.Responses) {
+		if !strings.HasPrefix(status, "2") {
+			continue
+		}
+
+		content, ok := op.Responses[status].Content["application/json"]
+		if !ok {
+			return "", nil
+		}
+
+		responseType, err := goType(content.Schema)
+		if err != nil {
+			return "", fmt.Errorf("response %s: %w", status, err)
+		}
+		return responseType, nil
+	}
+
+	return "", fmt.Errorf("no 2xx response")
+}
+
+// goType returns the Go type for a schema used by a property, parameter or body.
+func goType(s schema) (string, error) {
+	if s.Ref != "" {
+		return s.Ref[strings.LastIndex(s.Ref, "/")+1:], nil
+	}
+
+	if len(s.OneOf) > 0 {
+		var other []schema
+		for _, option := range s.OneOf {
+			if typeName(option) != "null" {
+				other = append(other, option)
+			}
+		}
+
+		if len(other) != 1 || len(other) == len(s.OneOf) {
+			return "", fmt.Errorf("only oneOf a single schema and null is supported")
+		}
+
+		elementType, err := goType(other[0])
+		if err != nil {
+			return "", err
+		}
+		return "*" + elementType, nil
+	}
+
+	if types, ok := s.Type.([]any); ok && slices.Contains(types, any("null")) {
+		elementType, err := goType(schema{Type: typeName(s), Format: s.Format, Items: s.Items})
+		if err != nil {
+			return "", err
+		}
+		return "*" + elementType, nil
+	}
+
+	switch typeName(s) {
+	case "integer":
+		if s.Format == "int32" {
+			return "int32", nil
+		}
+		return "int64", nil
+	case "number":
+		return "float64", nil
+	case "string":
+		return "string", nil
+	case "boolean":
+		return "bool", nil
+	case "array":
+		if s.Items == nil {
+			return "", fmt.Errorf("array without items")
+		}
+
+		elementType, err := goType(*s.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elementType, nil
+	}
+
+	return "", fmt.Errorf("unsupported schema type %v", s.Type)
+}
+
+// typeName returns the type of a schema, ignoring "null" in a list of types.
+func typeName(s schema) string {
+	switch t := s.Type.(type) {
+	case string:
+		return t
+	case []any:
+		for _, option := range t {
+			if name, ok := option.(string); ok && name != "null" {
+				return name
+			}
+		}
+		return "null"
+	}
+	return ""
+}
+
+// goName turns snake_case and camelCase names into exported Go names, keeping the
+// repository's Id spelling: chapter_id becomes ChapterId.
+func goName(name string) string {
+	var result strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' || r == ' ' }) {
+		result.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return result.String()
+}
+
+// argumentName turns a parameter name into an unexported Go identifier.
+func argumentName(name string) string {
+	exported := goName(name)
+	return strings.ToLower(exported[:1]) + exported[1:]
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
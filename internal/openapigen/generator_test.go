@@ -0,0 +1,71 @@
+package openapigen_test
+
+import (
+	"os"
+	"testing"
+
+	"terraform-provider-dataminded/internal/openapigen"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	specPath      = "../dataminded_api/openapi/api.json"
+	generatedPath = "../dataminded_api/openapi/openapi.gen.go"
+)
+
+func TestGeneratedCodeMatchesSpec(t *testing.T) {
+	document, err := os.ReadFile(specPath)
+	assert.Nil(t, err)
+
+	generated, err := os.ReadFile(generatedPath)
+	assert.Nil(t, err)
+
+	source, err := openapigen.Generate(document, "openapi")
+	assert.Nil(t, err)
+
+	assert.Equal(t, string(source), string(generated), "%s is out of date with %s, run 'go generate ./...'", generatedPath, specPath)
+}
+
+func TestGenerateNullableStringParameter(t *testing.T) {
+	document := []byte(`{
+		"info": {"title": "test", "version": "1"},
+		"paths": {
+			"/team/{slug}": {
+				"get": {
+					"operationId": "getTeam",
+					"description": "Get a team",
+					"parameters": [{"name": "slug", "in": "path", "schema": {"type": "string"}}],
+					"responses": {"200": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Team"}}}}}
+				}
+			}
+		},
+		"components": {
+			"schemas": {
+				"Team": {
+					"type": "object",
+					"required": ["team_id", "motto"],
+					"properties": {
+						"team_id": {"type": "integer", "format": "int64"},
+						"motto": {"type": ["string", "null"]}
+					}
+				}
+			}
+		}
+	}`)
+
+	source, err := openapigen.Generate(document, "teams")
+	assert.Nil(t, err)
+
+	assert.Regexp(t, "TeamId +int64 +`json:\"team_id\"`", string(source))
+	assert.Regexp(t, "Motto +\\*string +`json:\"motto\"`", string(source))
+	assert.Contains(t, string(source), `fmt.Sprintf("/team/%s", url.PathEscape(slug))`)
+}
+
+func TestGenerateRejectsMissingOperationId(t *testing.T) {
+	document := []byte(`{"paths": {"/user": {"get": {"responses": {"200": {}}}}}}`)
+
+	_, err := openapigen.Generate(document, "openapi")
+
+	assert.ErrorContains(t, err, "GET /user has no operationId")
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// setting is a value of the provider block, taken from its attribute or, when the
+// attribute is not set, from its environment variable. An empty environment variable
+// counts as not set.
+type setting struct {
+	attribute string
+	envVar    string
+	value     string
+
+	// fromAttribute and fromEnv tell where value came from. Neither is set when the
+	// value is missing.
+	fromAttribute bool
+	fromEnv       bool
+}
+
+func stringSetting(value types.String, attribute string, envVar string) setting {
+	s := setting{attribute: attribute, envVar: envVar}
+	if !value.IsNull() {
+		s.value, s.fromAttribute = value.ValueString(), true
+	} else {
+		s.value = os.Getenv(envVar)
+		s.fromEnv = s.value != ""
+	}
+	return s
+}
+
+func int64Setting(value types.Int64, attribute string, envVar string) setting {
+	if value.IsNull() {
+		return stringSetting(types.StringNull(), attribute, envVar)
+	}
+	return setting{attribute: attribute, envVar: envVar, value: strconv.FormatInt(value.ValueInt64(), 10), fromAttribute: true}
+}
+
+func boolSetting(value types.Bool, attribute string, envVar string) setting {
+	if value.IsNull() {
+		return stringSetting(types.StringNull(), attribute, envVar)
+	}
+	return setting{attribute: attribute, envVar: envVar, value: strconv.FormatBool(value.ValueBool()), fromAttribute: true}
+}
+
+func (s setting) isSet() bool {
+	return s.fromAttribute || s.fromEnv
+}
+
+// source describes where the value came from, for diagnostics.
+func (s setting) source() string {
+	switch {
+	case s.fromAttribute:
+		return fmt.Sprintf("%s: set by the %s attribute", s.attribute, s.attribute)
+	case s.fromEnv:
+		return fmt.Sprintf("%s: set by the %s environment variable", s.attribute, s.envVar)
+	default:
+		return fmt.Sprintf("%s: not set, neither by the %s attribute nor by the %s environment variable", s.attribute, s.attribute, s.envVar)
+	}
+}
+
+// int64Value parses the value, reporting an invalid environment variable as an error.
+func (s setting) int64Value(diagnostics *diag.Diagnostics) int64 {
+	value, err := strconv.ParseInt(s.value, 10, 64)
+	if err != nil {
+		diagnostics.AddAttributeError(path.Root(s.attribute), fmt.Sprintf("Invalid %s", s.envVar),
+			fmt.Sprintf("%s must be a number, got %q.", s.envVar, s.value))
+	}
+	return value
+}
+
+// boolValue parses the value, reporting an invalid environment variable as an error.
+func (s setting) boolValue(diagnostics *diag.Diagnostics) bool {
+	if !s.isSet() {
+		return false
+	}
+
+	value, err := strconv.ParseBool(s.value)
+	if err != nil {
+		diagnostics.AddAttributeError(path.Root(s.attribute), fmt.Sprintf("Invalid %s", s.envVar),
+			fmt.Sprintf("%s must be true or false, got %q.", s.envVar, s.value))
+	}
+	return value
+}
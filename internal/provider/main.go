@@ -5,6 +5,21 @@ import (
 )
 
 type ProviderConfigModel struct {
-	Host types.String `tfsdk:"host"`
-	Port types.Int64  `tfsdk:"port"`
+	Endpoint   types.String `tfsdk:"endpoint"`
+	Host       types.String `tfsdk:"host"`
+	Port       types.Int64  `tfsdk:"port"`
+	Preflight  types.Bool   `tfsdk:"preflight"`
+	MaxRetries types.Int64  `tfsdk:"max_retries"`
+	MinBackoff types.String `tfsdk:"min_backoff"`
+	MaxBackoff types.String `tfsdk:"max_backoff"`
+
+	Token        types.String `tfsdk:"token"`
+	ApiKey       types.String `tfsdk:"api_key"`
+	ApiKeyHeader types.String `tfsdk:"api_key_header"`
+
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	ClientCertPEM      types.String `tfsdk:"client_cert_pem"`
+	ClientKeyPEM       types.String `tfsdk:"client_key_pem"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
 }
@@ -5,6 +5,10 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
 
 	"terraform-provider-dataminded/internal/dataminded_api"
 	"terraform-provider-dataminded/internal/services/chapter"
@@ -13,15 +17,19 @@ import (
 	"terraform-provider-dataminded/internal/services/user"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure ScaffoldingProvider satisfies various provider interfaces.
 var _ provider.Provider = &datamindedProvider{}
 var _ provider.ProviderWithFunctions = &datamindedProvider{}
+var _ provider.ProviderWithValidateConfig = &datamindedProvider{}
 
 // ScaffoldingProvider defines the provider implementation.
 type datamindedProvider struct {
@@ -38,19 +46,102 @@ func (p *datamindedProvider) Metadata(ctx context.Context, req provider.Metadata
 
 func (p *datamindedProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the users and chapters of the Dataminded API. " +
+			"The endpoint, authentication and TLS attributes fall back to the environment variable named in their " +
+			"description, so that workspaces and CI jobs need not hardcode them. An attribute set in the provider " +
+			"block takes precedence over its environment variable. `endpoint` cannot be combined with the deprecated " +
+			"`host` and `port` from the same source.",
 		Attributes: map[string]schema.Attribute{
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "URL of the Dataminded API, such as `http://localhost:3000` or `https://ingress.example.com/dataminded/v1`. " +
+					"An API that listens on a unix socket is reached with `unix:///run/dataminded.sock`, " +
+					"with an optional base path in the `base_path` query parameter: `unix:///run/dataminded.sock?base_path=/dataminded/v1`. " +
+					"Falls back to the `DATAMINDED_ENDPOINT` environment variable.",
+				Optional: true,
+			},
 			"host": schema.StringAttribute{
-				MarkdownDescription: "Host address where the Dataminded API runs",
-				Required:            true,
+				MarkdownDescription: "Host address where the Dataminded API runs, including the scheme. Falls back to the `DATAMINDED_HOST` environment variable.",
+				Optional:            true,
+				DeprecationMessage:  "Use endpoint instead, for example endpoint = \"http://localhost:3000\".",
 			},
 			"port": schema.Int64Attribute{
-				MarkdownDescription: "Port of the Dataminded API host",
-				Required:            true,
+				MarkdownDescription: "Port of the Dataminded API host. Falls back to the `DATAMINDED_PORT` environment variable.",
+				Optional:            true,
+				DeprecationMessage:  "Use endpoint instead, for example endpoint = \"http://localhost:3000\".",
+			},
+			"preflight": schema.BoolAttribute{
+				MarkdownDescription: "Check that the API is reachable and compatible with the provider when configuring it, " +
+					"by fetching its OpenAPI document from `/api.json`. Defaults to `false`.",
+				Optional: true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Number of times a request that failed with a transient error is retried. Defaults to `3`, `0` disables retries.",
+				Optional:            true,
+			},
+			"min_backoff": schema.StringAttribute{
+				MarkdownDescription: "Delay before the first retry, as a duration such as `500ms`. Doubles on every following retry. Defaults to `500ms`.",
+				Optional:            true,
+			},
+			"max_backoff": schema.StringAttribute{
+				MarkdownDescription: "Upper bound of the delay between two retries, as a duration such as `10s`. Defaults to `10s`.",
+				Optional:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Bearer token sent in the `Authorization` header of every request. Falls back to the `DATAMINDED_TOKEN` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"api_key": schema.StringAttribute{
+				MarkdownDescription: "API key sent in the `api_key_header` header of every request. Falls back to the `DATAMINDED_API_KEY` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"api_key_header": schema.StringAttribute{
+				MarkdownDescription: "Header that carries the `api_key`. Falls back to the `DATAMINDED_API_KEY_HEADER` environment variable. Defaults to `X-API-Key`.",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded CA bundle used to verify the certificate of an HTTPS API, instead of the system roots. Conflicts with `ca_cert_file`. Falls back to the `DATAMINDED_CA_CERT_PEM` environment variable.",
+				Optional:            true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a PEM-encoded CA bundle used to verify the certificate of an HTTPS API, instead of the system roots. Conflicts with `ca_cert_pem`. Falls back to the `DATAMINDED_CA_CERT_FILE` environment variable.",
+				Optional:            true,
+			},
+			"client_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded client certificate presented to an API that requires mutual TLS. Requires `client_key_pem`. Falls back to the `DATAMINDED_CLIENT_CERT_PEM` environment variable.",
+				Optional:            true,
+			},
+			"client_key_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded private key of `client_cert_pem`. Falls back to the `DATAMINDED_CLIENT_KEY_PEM` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip verification of the certificate of an HTTPS API. Only meant for development. Falls back to the `DATAMINDED_INSECURE_SKIP_VERIFY` environment variable.",
+				Optional:            true,
 			},
 		},
 	}
 }
 
+// ValidateConfig reports a missing, conflicting or invalid endpoint, such as one
+// without a scheme or with a port outside 1-65535, before Terraform plans anything.
+// The diagnostics name where each part of the endpoint came from.
+func (p *datamindedProvider) ValidateConfig(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
+	var data ProviderConfigModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	// Input variables are unknown while validating. Configure reports an endpoint
+	// that is still unknown when Terraform plans
+	if resp.Diagnostics.HasError() || len(unknownEndpoint(data)) > 0 {
+		return
+	}
+
+	resolveEndpoint(data, &resp.Diagnostics)
+}
+
 func (p *datamindedProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	var data ProviderConfigModel
 
@@ -60,13 +151,176 @@ func (p *datamindedProvider) Configure(ctx context.Context, req provider.Configu
 		return
 	}
 
-	connection := dataminded_api.Connection{
-		Host: data.Host.ValueString(),
-		Port: data.Port.ValueInt64(),
+	// Terraform configures the provider during plan as well, before the resources
+	// that the endpoint may come from are applied
+	for _, attribute := range unknownEndpoint(data) {
+		resp.Diagnostics.AddAttributeError(attribute, "Unknown endpoint",
+			fmt.Sprintf("%s depends on a value that is only known after apply, but the provider needs its endpoint "+
+				"to plan. Set it to a literal or an input variable, or apply the resources it depends on first "+
+				"with -target.", attribute))
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	endpoint := resolveEndpoint(data, &resp.Diagnostics)
+
+	retry := dataminded_api.DefaultRetryPolicy
+
+	if !data.MaxRetries.IsNull() {
+		retry.MaxRetries = int(data.MaxRetries.ValueInt64())
 	}
 
-	resp.DataSourceData = &connection
-	resp.ResourceData = &connection
+	if retry.MaxRetries < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_retries"), "Invalid max_retries",
+			fmt.Sprintf("max_retries must be zero or more, got %d.", retry.MaxRetries))
+	}
+
+	retry.MinBackoff = parseDuration(data.MinBackoff, path.Root("min_backoff"), retry.MinBackoff, &resp.Diagnostics)
+	retry.MaxBackoff = parseDuration(data.MaxBackoff, path.Root("max_backoff"), retry.MaxBackoff, &resp.Diagnostics)
+
+	if retry.MinBackoff > retry.MaxBackoff {
+		resp.Diagnostics.AddAttributeError(path.Root("min_backoff"), "Invalid min_backoff",
+			fmt.Sprintf("min_backoff (%s) must not exceed max_backoff (%s).", retry.MinBackoff, retry.MaxBackoff))
+	}
+
+	tlsConfig, err := dataminded_api.TLSOptions{
+		CACertPEM:          stringSetting(data.CACertPEM, "ca_cert_pem", "DATAMINDED_CA_CERT_PEM").value,
+		CACertFile:         stringSetting(data.CACertFile, "ca_cert_file", "DATAMINDED_CA_CERT_FILE").value,
+		ClientCertPEM:      stringSetting(data.ClientCertPEM, "client_cert_pem", "DATAMINDED_CLIENT_CERT_PEM").value,
+		ClientKeyPEM:       stringSetting(data.ClientKeyPEM, "client_key_pem", "DATAMINDED_CLIENT_KEY_PEM").value,
+		InsecureSkipVerify: boolSetting(data.InsecureSkipVerify, "insecure_skip_verify", "DATAMINDED_INSECURE_SKIP_VERIFY").boolValue(&resp.Diagnostics),
+	}.Config()
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid TLS configuration", err.Error())
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := dataminded_api.NewClient(dataminded_api.Connection{
+		Endpoint:     endpoint,
+		Retry:        retry,
+		Token:        stringSetting(data.Token, "token", "DATAMINDED_TOKEN").value,
+		ApiKey:       stringSetting(data.ApiKey, "api_key", "DATAMINDED_API_KEY").value,
+		ApiKeyHeader: stringSetting(data.ApiKeyHeader, "api_key_header", "DATAMINDED_API_KEY_HEADER").value,
+		TLSConfig:    tlsConfig,
+	})
+
+	if data.Preflight.ValueBool() {
+		preflight(ctx, client, endpoint, &resp.Diagnostics)
+	}
+
+	resp.DataSourceData = client
+	resp.ResourceData = client
+}
+
+// preflight reports an unreachable or incompatible API as a single diagnostic, rather
+// than as a failure of the first resource that calls it.
+func preflight(ctx context.Context, client *dataminded_api.Client, endpoint *url.URL, diagnostics *diag.Diagnostics) {
+	err := client.Preflight(ctx)
+
+	switch {
+	case err == nil:
+	case errors.Is(err, dataminded_api.ErrIncompatibleAPI):
+		diagnostics.AddAttributeError(path.Root("endpoint"), "Incompatible Dataminded API",
+			fmt.Sprintf("The API at %s is not the one this provider was built for: %s.\n\n"+
+				"Check that endpoint points at the Dataminded API, or use a provider version that matches the API.", endpoint, err))
+	default:
+		diagnostics.AddAttributeError(path.Root("endpoint"), "Unreachable Dataminded API",
+			fmt.Sprintf("The provider could not fetch the OpenAPI document of the API at %s: %s\n\n"+
+				"Check that the API is running and that endpoint holds its scheme, host, port and base path.", endpoint, err))
+	}
+}
+
+// unknownEndpoint returns the attributes of the endpoint whose value is not known yet.
+func unknownEndpoint(data ProviderConfigModel) []path.Path {
+	var attributes []path.Path
+	if data.Endpoint.IsUnknown() {
+		attributes = append(attributes, path.Root("endpoint"))
+	}
+	if data.Host.IsUnknown() {
+		attributes = append(attributes, path.Root("host"))
+	}
+	if data.Port.IsUnknown() {
+		attributes = append(attributes, path.Root("port"))
+	}
+	return attributes
+}
+
+// resolveEndpoint determines the endpoint from the endpoint attribute or the deprecated
+// host and port attributes, falling back to their environment variables. Attributes
+// take precedence over environment variables, so that an endpoint in the environment
+// does not clash with host and port in the provider block, nor the other way around.
+func resolveEndpoint(data ProviderConfigModel, diagnostics *diag.Diagnostics) *url.URL {
+	endpoint := stringSetting(data.Endpoint, "endpoint", "DATAMINDED_ENDPOINT")
+	host := stringSetting(data.Host, "host", "DATAMINDED_HOST")
+	port := int64Setting(data.Port, "port", "DATAMINDED_PORT")
+
+	hostPortFromAttribute := host.fromAttribute || port.fromAttribute
+	hostPortFromEnv := host.fromEnv || port.fromEnv
+
+	switch {
+	case endpoint.fromAttribute && hostPortFromAttribute, endpoint.fromEnv && !hostPortFromAttribute && hostPortFromEnv:
+		diagnostics.AddAttributeError(path.Root("endpoint"), "Conflicting endpoint configuration",
+			fmt.Sprintf("endpoint cannot be combined with the deprecated host and port. Remove host and port, "+
+				"endpoint = %q replaces them.\n\n%s\n%s\n%s", endpoint.value, endpoint.source(), host.source(), port.source()))
+		return nil
+	case endpoint.fromAttribute || (endpoint.fromEnv && !hostPortFromAttribute):
+		return parseEndpoint(endpoint.value, path.Root("endpoint"), diagnostics)
+	case !host.isSet() || !port.isSet():
+		diagnostics.AddAttributeError(path.Root("endpoint"), "Missing endpoint",
+			fmt.Sprintf("Set the endpoint attribute or the DATAMINDED_ENDPOINT environment variable to the URL of the "+
+				"Dataminded API, such as \"http://localhost:3000\".\n\n%s\n%s\n%s", endpoint.source(), host.source(), port.source()))
+		return nil
+	}
+
+	portNumber := port.int64Value(diagnostics)
+	if diagnostics.HasError() {
+		return nil
+	}
+
+	if portNumber < 1 || portNumber > 65535 {
+		diagnostics.AddAttributeError(path.Root("port"), "Invalid port",
+			fmt.Sprintf("port must be between 1 and 65535, got %d.\n\n%s", portNumber, port.source()))
+		return nil
+	}
+
+	return parseEndpoint(dataminded_api.HostPortEndpoint(host.value, portNumber), path.Root("host"), diagnostics)
+}
+
+func parseEndpoint(raw string, attribute path.Path, diagnostics *diag.Diagnostics) *url.URL {
+	endpoint, err := dataminded_api.ParseEndpoint(raw)
+	if err != nil {
+		diagnostics.AddAttributeError(attribute, "Invalid endpoint", err.Error())
+		return nil
+	}
+
+	return endpoint
+}
+
+// parseDuration reads a duration attribute of the provider block, falling back to
+// defaultValue when it is not set.
+func parseDuration(value types.String, attribute path.Path, defaultValue time.Duration, diagnostics *diag.Diagnostics) time.Duration {
+	if value.IsNull() {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value.ValueString())
+	if err == nil && duration < 0 {
+		err = fmt.Errorf("duration must not be negative")
+	}
+
+	if err != nil {
+		diagnostics.AddAttributeError(attribute, "Invalid duration",
+			fmt.Sprintf("Expected a duration such as \"500ms\" or \"2s\", got %q: %s.", value.ValueString(), err))
+		return defaultValue
+	}
+
+	return duration
 }
 
 func (p *datamindedProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -78,12 +332,21 @@ func (p *datamindedProvider) Resources(ctx context.Context) []func() resource.Re
 }
 
 func (p *datamindedProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		user.NewUserDataSource,
+		user.NewUsersDataSource,
+		chapter.NewChapterDataSource,
+		chapter.NewChaptersDataSource,
+	}
 }
 
 func (p *datamindedProvider) Functions(ctx context.Context) []func() function.Function {
 	return []func() function.Function{
 		functions.NewConfigParser,
+		functions.NewConfigRoster,
+		functions.NewConfigMerge,
+		functions.NewConfigDiff,
+		functions.NewConfigRender,
 	}
 }
 
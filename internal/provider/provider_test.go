@@ -0,0 +1,525 @@
+package provider_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"terraform-provider-dataminded/internal/acceptance"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+type Provider struct{}
+
+func TestAccBearerToken(t *testing.T) {
+	data := acceptance.AuthenticatingProxy(t, acceptance.BuildTestData(t), func(r *http.Request) bool {
+		return r.Header.Get("Authorization") == "Bearer s3cr3t"
+	})
+	p := Provider{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   p.user(data, ``),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ExpectError:              regexp.MustCompile(`returned status 401`),
+			},
+			{
+				Config:                   p.user(data, `token = "wrong"`),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ExpectError:              regexp.MustCompile(`returned status 401`),
+			},
+			{
+				Config:                   p.user(data, `token = "s3cr3t"`),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dataminded_user.test", "name", fmt.Sprintf("test_%s", data.RandomString)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccApiKey(t *testing.T) {
+	data := acceptance.AuthenticatingProxy(t, acceptance.BuildTestData(t), func(r *http.Request) bool {
+		return r.Header.Get("X-Gateway-Key") == "k3y"
+	})
+	p := Provider{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   p.user(data, `api_key = "k3y"`),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ExpectError:              regexp.MustCompile(`returned status 401`),
+			},
+			{
+				Config: p.user(data, `
+					api_key        = "k3y"
+					api_key_header = "X-Gateway-Key"
+				`),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dataminded_user.test", "name", fmt.Sprintf("test_%s", data.RandomString)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCredentialsFromEnvironment(t *testing.T) {
+	data := acceptance.AuthenticatingProxy(t, acceptance.BuildTestData(t), func(r *http.Request) bool {
+		return r.Header.Get("Authorization") == "Bearer from-env" && r.Header.Get("X-API-Key") == "key-from-env"
+	})
+	p := Provider{}
+
+	t.Setenv("DATAMINDED_TOKEN", "from-env")
+	t.Setenv("DATAMINDED_API_KEY", "key-from-env")
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   p.user(data, ``),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dataminded_user.test", "name", fmt.Sprintf("test_%s", data.RandomString)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCACertificate(t *testing.T) {
+	data := acceptance.TLSProxy(t, acceptance.BuildTestData(t), false)
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caCertFile, []byte(data.CACertPEM), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	p := Provider{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   p.user(data.TestData, ``),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ExpectError:              regexp.MustCompile(`certificate`),
+			},
+			{
+				Config:                   p.user(data.TestData, fmt.Sprintf(`ca_cert_file = %q`, caCertFile)),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dataminded_user.test", "name", fmt.Sprintf("test_%s", data.RandomString)),
+				),
+			},
+			{
+				Config:                   p.user(data.TestData, fmt.Sprintf(`ca_cert_pem = %q`, data.CACertPEM)),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				PlanOnly:                 true,
+			},
+			{
+				Config:                   p.user(data.TestData, `insecure_skip_verify = true`),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				PlanOnly:                 true,
+			},
+		},
+	})
+}
+
+func TestAccClientCertificate(t *testing.T) {
+	data := acceptance.TLSProxy(t, acceptance.BuildTestData(t), true)
+	p := Provider{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   p.user(data.TestData, fmt.Sprintf(`ca_cert_pem = %q`, data.CACertPEM)),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ExpectError:              regexp.MustCompile(`certificate`),
+			},
+			{
+				Config: p.user(data.TestData, fmt.Sprintf(`
+					ca_cert_pem     = %q
+					client_cert_pem = %q
+				`, data.CACertPEM, data.ClientCertPEM)),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ExpectError:              regexp.MustCompile(`must be set together`),
+			},
+			{
+				Config: p.user(data.TestData, fmt.Sprintf(`
+					ca_cert_pem     = %q
+					client_cert_pem = %q
+					client_key_pem  = %q
+				`, data.CACertPEM, data.ClientCertPEM, data.ClientKeyPEM)),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dataminded_user.test", "name", fmt.Sprintf("test_%s", data.RandomString)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccEndpointBasePath(t *testing.T) {
+	data := acceptance.PathPrefixProxy(t, acceptance.BuildTestData(t), "/dataminded/v1")
+	p := Provider{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   p.user(data, ``),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dataminded_user.test", "name", fmt.Sprintf("test_%s", data.RandomString)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccEndpointUnixSocket(t *testing.T) {
+	data := acceptance.UnixSocketProxy(t, acceptance.BuildTestData(t))
+	p := Provider{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   p.user(data, ``),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dataminded_user.test", "name", fmt.Sprintf("test_%s", data.RandomString)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDeprecatedHostAndPort(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	p := Provider{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   p.user_host_port(data, ``),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dataminded_user.test", "name", fmt.Sprintf("test_%s", data.RandomString)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccConflictingEndpoint(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	p := Provider{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   p.user_host_port(data, fmt.Sprintf(`endpoint = "%s"`, data.Endpoint)),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ExpectError:              regexp.MustCompile(`Conflicting endpoint configuration`),
+			},
+		},
+	})
+}
+
+func TestAccInvalidEndpoint(t *testing.T) {
+	t.Setenv("DATAMINDED_ENDPOINT", "")
+	t.Setenv("DATAMINDED_HOST", "")
+	t.Setenv("DATAMINDED_PORT", "")
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "dataminded" {
+						endpoint = "localhost"
+					}
+
+					resource "dataminded_user" "test" {
+						name = "test"
+					}
+				`,
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ExpectError:              regexp.MustCompile(`has no scheme`),
+			},
+			{
+				Config: `
+					provider "dataminded" {}
+
+					resource "dataminded_user" "test" {
+						name = "test"
+					}
+				`,
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ExpectError:              regexp.MustCompile(`Missing endpoint`),
+			},
+		},
+	})
+}
+
+func TestAccUnknownEndpoint(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "terraform_data" "endpoint" {
+						input = "%s"
+					}
+
+					provider "dataminded" {
+						endpoint = terraform_data.endpoint.output
+					}
+
+					resource "dataminded_user" "test" {
+						name = "test"
+					}
+				`, data.Endpoint),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ExpectError:              regexp.MustCompile(`(?s)Unknown endpoint.*endpoint depends on a value that is only known\s+after apply`),
+			},
+		},
+	})
+}
+
+func TestAccEndpointFromEnvironment(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	p := Provider{}
+
+	t.Setenv("DATAMINDED_ENDPOINT", data.Endpoint.String())
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   p.user_from_environment(data),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dataminded_user.test", "name", fmt.Sprintf("test_%s", data.RandomString)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccHostAndPortFromEnvironment(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	p := Provider{}
+
+	t.Setenv("DATAMINDED_ENDPOINT", "")
+	t.Setenv("DATAMINDED_HOST", data.Endpoint.Scheme+"://"+data.Endpoint.Hostname())
+	t.Setenv("DATAMINDED_PORT", data.Endpoint.Port())
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   p.user_from_environment(data),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dataminded_user.test", "name", fmt.Sprintf("test_%s", data.RandomString)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAttributesTakePrecedenceOverEnvironment(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	p := Provider{}
+
+	// Nothing listens there, and the host and port would clash with the endpoint attribute
+	t.Setenv("DATAMINDED_ENDPOINT", "http://127.0.0.1:1")
+	t.Setenv("DATAMINDED_HOST", "http://127.0.0.1")
+	t.Setenv("DATAMINDED_PORT", "1")
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   p.user(data, ``),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dataminded_user.test", "name", fmt.Sprintf("test_%s", data.RandomString)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMissingEndpointNamesSources(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	p := Provider{}
+
+	t.Setenv("DATAMINDED_ENDPOINT", "")
+	t.Setenv("DATAMINDED_HOST", "http://localhost")
+	t.Setenv("DATAMINDED_PORT", "")
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   p.user_from_environment(data),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ExpectError:              regexp.MustCompile(`(?s)Missing endpoint.*host: set by the\s+DATAMINDED_HOST\s+environment\s+variable.*port: not set`),
+			},
+		},
+	})
+}
+
+func TestAccInvalidEnvironment(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	p := Provider{}
+
+	t.Setenv("DATAMINDED_ENDPOINT", "")
+	t.Setenv("DATAMINDED_HOST", "http://localhost")
+	t.Setenv("DATAMINDED_PORT", "three thousand")
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   p.user_from_environment(data),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ExpectError:              regexp.MustCompile(`DATAMINDED_PORT must be a number`),
+			},
+		},
+	})
+}
+
+func TestAccTLSFromEnvironment(t *testing.T) {
+	data := acceptance.TLSProxy(t, acceptance.BuildTestData(t), true)
+	p := Provider{}
+
+	t.Setenv("DATAMINDED_ENDPOINT", data.Endpoint.String())
+	t.Setenv("DATAMINDED_CA_CERT_PEM", data.CACertPEM)
+	t.Setenv("DATAMINDED_CLIENT_CERT_PEM", data.ClientCertPEM)
+	t.Setenv("DATAMINDED_CLIENT_KEY_PEM", data.ClientKeyPEM)
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   p.user_from_environment(data.TestData),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dataminded_user.test", "name", fmt.Sprintf("test_%s", data.RandomString)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPreflight(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	p := Provider{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   p.user(data, `preflight = true`),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dataminded_user.test", "name", fmt.Sprintf("test_%s", data.RandomString)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPreflightFailure(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	p := Provider{}
+
+	unreachable := data
+	unreachable.Endpoint = &url.URL{Scheme: "http", Host: "127.0.0.1:1"}
+
+	incompatible := data
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"info": {"title": "Petstore", "version": "1.0.0"}, "paths": {}}`))
+	}))
+	t.Cleanup(server.Close)
+	incompatible.Endpoint, _ = url.Parse(server.URL)
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: p.user(unreachable, `
+					preflight   = true
+					max_retries = 0
+				`),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ExpectError:              regexp.MustCompile(`Unreachable Dataminded API`),
+			},
+			{
+				Config:                   p.user(incompatible, `preflight = true`),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ExpectError:              regexp.MustCompile(`(?s)Incompatible Dataminded API.*"Petstore"`),
+			},
+		},
+	})
+}
+
+func TestAccPortOutOfRange(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	p := Provider{}
+
+	outOfRange := data
+	outOfRange.Endpoint = &url.URL{Scheme: "http", Host: "localhost:65536"}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   p.user(outOfRange, ``),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ExpectError:              regexp.MustCompile(`between 1\s+and 65535`),
+			},
+			{
+				Config:                   p.user_host_port(outOfRange, ``),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ExpectError:              regexp.MustCompile(`(?s)Invalid port.*port: set by the port attribute`),
+			},
+		},
+	})
+}
+
+func (p Provider) user(data acceptance.TestData, credentials string) string {
+	return fmt.Sprintf(`
+		provider "dataminded" {
+			endpoint = "%[1]s"
+			%[2]s
+		}
+
+		resource "dataminded_user" "test" {
+			name = "test_%[3]s"
+		}
+	`, data.Endpoint, credentials, data.RandomString)
+}
+
+func (p Provider) user_host_port(data acceptance.TestData, extra string) string {
+	return fmt.Sprintf(`
+		provider "dataminded" {
+			host = "%[1]s://%[2]s"
+			port = %[3]s
+			%[4]s
+		}
+
+		resource "dataminded_user" "test" {
+			name = "test_%[5]s"
+		}
+	`, data.Endpoint.Scheme, data.Endpoint.Hostname(), data.Endpoint.Port(), extra, data.RandomString)
+}
+
+func (p Provider) user_from_environment(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+		provider "dataminded" {}
+
+		resource "dataminded_user" "test" {
+			name = "test_%[1]s"
+		}
+	`, data.RandomString)
+}
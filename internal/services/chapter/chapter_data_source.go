@@ -0,0 +1,202 @@
+package chapter
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"terraform-provider-dataminded/internal/dataminded_api"
+	"terraform-provider-dataminded/internal/logging"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &ChapterDataSource{}
+	_ datasource.DataSourceWithConfigure        = &ChapterDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &ChapterDataSource{}
+)
+
+func NewChapterDataSource() datasource.DataSource {
+	return &ChapterDataSource{}
+}
+
+type ChapterDataSource struct {
+	Client *dataminded_api.Client
+}
+
+func (d *ChapterDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_chapter"
+}
+
+// membersAttribute describes the members of a chapter that have one role.
+func membersAttribute(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Computed:    true,
+		Description: description,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.Int64Attribute{
+					Computed:    true,
+					Description: "Id of the user",
+				},
+				"name": schema.StringAttribute{
+					Computed:    true,
+					Description: "Name of the user",
+				},
+			},
+		},
+	}
+}
+
+func (d *ChapterDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Look up a Dataminded chapter and its members by id or by name",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Id of the chapter in the sqlite database. Exactly one of id and name must be set.",
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Exact name of the chapter. Exactly one of id and name must be set, a name shared by several chapters is an error.",
+			},
+			"leads":        membersAttribute("Members with the Lead role, sorted by name."),
+			"contributors": membersAttribute("Members with the Contributor role, sorted by name."),
+		},
+	}
+}
+
+func (d *ChapterDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("name")),
+	}
+}
+
+func (d *ChapterDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = logging.WithDiagnostics(ctx, &resp.Diagnostics)
+
+	var config ChapterDataSourceModel
+
+	// Read input configured in data block
+	resp.Diagnostics.Append(
+		req.Config.Get(ctx, &config)...,
+	)
+
+	if logging.HasError(ctx) {
+		return
+	}
+
+	id := int(config.Id.ValueInt64())
+
+	if !config.Name.IsNull() {
+		var err error
+		id, err = d.Client.ChapterIdByName(ctx, config.Name.ValueString())
+
+		if err != nil {
+			logging.AddError(ctx, "Looking up chapter by name failed", err)
+			return
+		}
+	}
+
+	chapter, err := d.Client.ReadChapter(ctx, id)
+
+	if err != nil {
+		logging.AddError(ctx, "Reading chapter failed", err)
+		return
+	}
+
+	members, err := d.Client.ListChapterMembers(ctx, chapter.Id)
+
+	if err != nil {
+		logging.AddError(ctx, "Reading chapter members failed", err)
+		return
+	}
+
+	users, err := d.Client.ListUsers(ctx)
+
+	if err != nil {
+		logging.AddError(ctx, "Reading chapter members failed", err)
+		return
+	}
+
+	state := chapterModel(chapter, members, users)
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// chapterModel groups the members of the chapter by role. Members of other
+// chapters among members are skipped, so all members can be passed at once.
+func chapterModel(chapter dataminded_api.Chapter, members []dataminded_api.ChapterMember, users []dataminded_api.User) ChapterDataSourceModel {
+	names := map[int]string{}
+	for _, user := range users {
+		names[user.Id] = user.Name
+	}
+
+	// The groups are listed regardless, an empty list is more useful than null
+	model := ChapterDataSourceModel{
+		Id:           types.Int64Value(int64(chapter.Id)),
+		Name:         types.StringValue(chapter.Name),
+		Leads:        []ChapterMemberModel{},
+		Contributors: []ChapterMemberModel{},
+	}
+
+	for _, member := range members {
+		if member.ChapterId != chapter.Id {
+			continue
+		}
+
+		summary := ChapterMemberModel{
+			Id:   types.Int64Value(int64(member.UserId)),
+			Name: types.StringValue(names[member.UserId]),
+		}
+
+		if member.Role == dataminded_api.RoleLead {
+			model.Leads = append(model.Leads, summary)
+		} else {
+			model.Contributors = append(model.Contributors, summary)
+		}
+	}
+
+	sortMembers(model.Leads)
+	sortMembers(model.Contributors)
+
+	return model
+}
+
+func sortMembers(members []ChapterMemberModel) {
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].Name.ValueString() != members[j].Name.ValueString() {
+			return members[i].Name.ValueString() < members[j].Name.ValueString()
+		}
+		return members[i].Id.ValueInt64() < members[j].Id.ValueInt64()
+	})
+}
+
+func (d *ChapterDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*dataminded_api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Configure Type",
+			fmt.Sprintf("Expected *dataminded_api.Client got: %T.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.Client = client
+}
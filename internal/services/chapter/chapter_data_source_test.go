@@ -0,0 +1,233 @@
+package chapter_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"terraform-provider-dataminded/internal/acceptance"
+	"terraform-provider-dataminded/internal/dataminded_api"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+type ChapterDataSource struct{}
+
+func TestAccChapterDataSourceById(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	d := ChapterDataSource{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   d.chapter_lookup(connection, data.RandomString, `id = dataminded_chapter.platform.id`),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dataminded_chapter.test", "name", fmt.Sprintf("platform_%s", data.RandomString)),
+					resource.TestCheckResourceAttr("data.dataminded_chapter.test", "leads.#", "1"),
+					resource.TestCheckResourceAttrPair("data.dataminded_chapter.test", "leads.0.id", "dataminded_user.carol", "id"),
+					resource.TestCheckResourceAttr("data.dataminded_chapter.test", "leads.0.name", fmt.Sprintf("test_%s_carol", data.RandomString)),
+					// Contributors are sorted by name
+					resource.TestCheckResourceAttr("data.dataminded_chapter.test", "contributors.#", "2"),
+					resource.TestCheckResourceAttrPair("data.dataminded_chapter.test", "contributors.0.id", "dataminded_user.alice", "id"),
+					resource.TestCheckResourceAttrPair("data.dataminded_chapter.test", "contributors.1.id", "dataminded_user.bob", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccChapterDataSourceByName(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	d := ChapterDataSource{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   d.chapter_lookup(connection, data.RandomString, `name = dataminded_chapter.data.name`),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.dataminded_chapter.test", "id", "dataminded_chapter.data", "id"),
+					resource.TestCheckResourceAttr("data.dataminded_chapter.test", "leads.#", "0"),
+					resource.TestCheckResourceAttr("data.dataminded_chapter.test", "contributors.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccChapterDataSourceInvalidLookup(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	d := ChapterDataSource{}
+
+	tests := []struct {
+		name    string
+		lookup  string
+		message string
+	}{
+		{
+			name:    "neither",
+			lookup:  ``,
+			message: `(?s)Missing Attribute Configuration.*\[id,name\]`,
+		},
+		{
+			name:    "both",
+			lookup:  `id = 1` + "\n" + `name = "platform"`,
+			message: `(?s)Invalid Attribute Combination.*\[id,name\]`,
+		},
+		{
+			name:    "unknown name",
+			lookup:  fmt.Sprintf(`name = "missing_%s"`, data.RandomString),
+			message: `no chapter is named\s+"missing_`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				Steps: []resource.TestStep{
+					{
+						Config:                   d.chapter_lookup(connection, data.RandomString, test.lookup),
+						ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+						ExpectError:              regexp.MustCompile(test.message),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccChaptersDataSource(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	d := ChapterDataSource{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   d.chapters_filtered(connection, data.RandomString, fmt.Sprintf(`name_regex = "_%s$"`, data.RandomString)),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dataminded_chapters.test", "chapters.#", "2"),
+					// Chapters are sorted by name
+					resource.TestCheckResourceAttrPair("data.dataminded_chapters.test", "chapters.0.id", "dataminded_chapter.data", "id"),
+					resource.TestCheckResourceAttr("data.dataminded_chapters.test", "chapters.0.contributors.#", "0"),
+					resource.TestCheckResourceAttrPair("data.dataminded_chapters.test", "chapters.1.id", "dataminded_chapter.platform", "id"),
+					resource.TestCheckResourceAttrPair("data.dataminded_chapters.test", "chapters.1.leads.0.id", "dataminded_user.carol", "id"),
+					resource.TestCheckResourceAttr("data.dataminded_chapters.test", "chapters.1.contributors.#", "2"),
+				),
+			},
+			{
+				Config:                   d.chapters_filtered(connection, data.RandomString, fmt.Sprintf(`name_prefix = "platform_%s"`, data.RandomString)),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dataminded_chapters.test", "chapters.#", "1"),
+					resource.TestCheckResourceAttrPair("data.dataminded_chapters.test", "chapters.0.id", "dataminded_chapter.platform", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccChaptersDataSourceInvalidRegex(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	d := ChapterDataSource{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   d.chapters_filtered(connection, data.RandomString, `name_regex = "platform_("`),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ExpectError:              regexp.MustCompile(`Invalid regular expression`),
+			},
+		},
+	})
+}
+
+func (d ChapterDataSource) chapter_lookup(connection dataminded_api.Connection, name string, lookup string) string {
+	template := d.template(connection, name)
+
+	return fmt.Sprintf(
+		`
+		%[1]s
+
+		data "dataminded_chapter" "test" {
+			%[2]s
+
+			depends_on = [dataminded_chapter_member.alice, dataminded_chapter_member.bob, dataminded_chapter_member.carol]
+		}
+		`, template, lookup)
+}
+
+func (d ChapterDataSource) chapters_filtered(connection dataminded_api.Connection, name string, filters string) string {
+	template := d.template(connection, name)
+
+	return fmt.Sprintf(
+		`
+		%[1]s
+
+		data "dataminded_chapters" "test" {
+			%[2]s
+
+			depends_on = [dataminded_chapter.data, dataminded_chapter_member.alice, dataminded_chapter_member.bob, dataminded_chapter_member.carol]
+		}
+		`, template, filters)
+}
+
+func (d ChapterDataSource) template(connection dataminded_api.Connection, name string) string {
+	return fmt.Sprintf(`
+		provider "dataminded" {
+			endpoint = "%[1]s"
+		}
+
+		resource "dataminded_chapter" "platform" {
+			name = "platform_%[2]s"
+		}
+
+		resource "dataminded_chapter" "data" {
+			name = "data_%[2]s"
+		}
+
+		resource "dataminded_user" "bob" {
+			name = "test_%[2]s_bob"
+		}
+
+		resource "dataminded_user" "alice" {
+			name = "test_%[2]s_alice"
+		}
+
+		resource "dataminded_user" "carol" {
+			name = "test_%[2]s_carol"
+		}
+
+		resource "dataminded_chapter_member" "bob" {
+			chapter = dataminded_chapter.platform.id
+			member  = dataminded_user.bob.id
+		}
+
+		resource "dataminded_chapter_member" "alice" {
+			chapter = dataminded_chapter.platform.id
+			member  = dataminded_user.alice.id
+		}
+
+		resource "dataminded_chapter_member" "carol" {
+			chapter = dataminded_chapter.platform.id
+			member  = dataminded_user.carol.id
+			role    = "Lead"
+		}
+	`, connection.Endpoint, name)
+}
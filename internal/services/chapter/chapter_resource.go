@@ -2,19 +2,27 @@ package chapter
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"terraform-provider-dataminded/internal/dataminded_api"
 	"terraform-provider-dataminded/internal/logging"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &ChapterResource{}
-	_ resource.ResourceWithConfigure = &ChapterResource{}
+	_ resource.Resource                = &ChapterResource{}
+	_ resource.ResourceWithConfigure   = &ChapterResource{}
+	_ resource.ResourceWithImportState = &ChapterResource{}
 )
 
 func NewChapterResource() resource.Resource {
@@ -22,7 +30,7 @@ func NewChapterResource() resource.Resource {
 }
 
 type ChapterResource struct {
-	Connection dataminded_api.Connection
+	Client *dataminded_api.Client
 }
 
 func (r *ChapterResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -32,7 +40,20 @@ func (r *ChapterResource) Metadata(_ context.Context, req resource.MetadataReque
 func (r *ChapterResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manage Dataminded chapters",
-		Attributes:  map[string]schema.Attribute{},
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Id of the chapter in the sqlite database.",
+				// A rename keeps the id, so the plan need not show it as unknown
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the chapter",
+			},
+		},
 	}
 }
 
@@ -46,7 +67,17 @@ func (r *ChapterResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	// TODO: use plan to create the chapter, and return result to the state
+	name := plan.Name.ValueString()
+
+	chapter, err := r.Client.CreateChapter(ctx, name)
+
+	if err != nil {
+		logging.AddError(ctx, "Chapter creation failed", err)
+		return
+	}
+
+	// Chapter creation successful --> Set state of computed variables (Id)
+	plan.Id = types.Int64Value(int64(chapter.Id))
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -65,8 +96,23 @@ func (r *ChapterResource) Read(ctx context.Context, req resource.ReadRequest, re
 	if logging.HasError(ctx) {
 		return
 	}
-	// TODO: use state to read the chapter, and return result to the state.
-	// If the chapter does not exist, mark it for deletion from the state.
+
+	id := state.Id.ValueInt64()
+	chapter, err := r.Client.ReadChapter(ctx, int(id))
+
+	if errors.Is(err, dataminded_api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		logging.AddError(ctx, "Reading chapter failed", err)
+		return
+	}
+
+	// Set the read values
+	// We don't have to set Id since this value was used to read
+	state.Name = types.StringValue(chapter.Name)
 
 	diags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -89,7 +135,18 @@ func (r *ChapterResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	// TODO: use the plan to update the chapter
+	id := int(state.Id.ValueInt64())
+	newName := plan.Name.ValueString()
+
+	chapter, err := r.Client.UpdateChapter(ctx, id, newName)
+
+	if err != nil {
+		logging.AddError(ctx, "Updating chapter failed", err)
+		return
+	}
+
+	// Chapter update successful --> Set state of computed variables (Id)
+	plan.Id = types.Int64Value(int64(chapter.Id))
 
 	diags := resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -109,7 +166,45 @@ func (r *ChapterResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	// TODO: use state to delete the chapter
+	id := int(state.Id.ValueInt64())
+
+	err := r.Client.DeleteChapter(ctx, id)
+
+	// A chapter that is already gone needs no dropping
+	if err != nil && !errors.Is(err, dataminded_api.ErrNotFound) {
+		logging.AddError(ctx, "Dropping chapter failed", err)
+	}
+}
+
+// ImportState adopts an existing chapter by its numeric id or by name:<name>, Read
+// fills in the rest.
+func (r *ChapterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = logging.WithDiagnostics(ctx, &resp.Diagnostics)
+
+	if name, found := strings.CutPrefix(req.ID, "name:"); found {
+		id, err := r.Client.ChapterIdByName(ctx, name)
+
+		if err != nil {
+			logging.AddError(ctx, "Importing chapter by name failed", err)
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), int64(id))...)
+		return
+	}
+
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid import id",
+			fmt.Sprintf("Expected the numeric id of the chapter or name:<name>, got: %q.", req.ID),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
 
 func (r *ChapterResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -118,16 +213,16 @@ func (r *ChapterResource) Configure(_ context.Context, req resource.ConfigureReq
 		return
 	}
 
-	connection, ok := req.ProviderData.(*dataminded_api.Connection)
+	client, ok := req.ProviderData.(*dataminded_api.Client)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Configure Type",
-			fmt.Sprintf("Expected *dataminded_api.Connection got: %T.", req.ProviderData),
+			fmt.Sprintf("Expected *dataminded_api.Client got: %T.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.Connection = *connection
+	r.Client = client
 }
@@ -2,6 +2,8 @@ package chapter_test
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"testing"
 
 	"terraform-provider-dataminded/internal/acceptance"
@@ -15,22 +17,180 @@ type ChapterResource struct{}
 func TestAccCreateChapter(t *testing.T) {
 	data := acceptance.BuildTestData(t)
 	connection := dataminded_api.Connection{
-		Host: data.Host,
-		Port: data.Port,
+		Endpoint: data.Endpoint,
 	}
 	r := ChapterResource{}
 
 	resource.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
-				Config:                   r.chapter_basic(connection),
+				Config:                   r.chapter_basic(connection, data.RandomString),
 				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dataminded_chapter.test", "name", fmt.Sprintf("test_%s", data.RandomString)),
+					resource.TestCheckResourceAttrSet("dataminded_chapter.test", "id"),
+				),
 			},
 		},
 	})
 }
 
-func (r ChapterResource) chapter_basic(connection dataminded_api.Connection) string {
+func TestAccUpdateChapter(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	r := ChapterResource{}
+
+	var id string
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   r.chapter_basic(connection, data.RandomString),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.TestCheckResourceAttrWith("dataminded_chapter.test", "id", func(value string) error {
+					id = value
+					return nil
+				}),
+			},
+			{
+				Config:                   r.chapter_basic(connection, fmt.Sprintf("%s2", data.RandomString)),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dataminded_chapter.test", "name", fmt.Sprintf("test_%s2", data.RandomString)),
+					// The chapter is renamed in place rather than replaced
+					resource.TestCheckResourceAttrPtr("dataminded_chapter.test", "id", &id),
+				),
+			},
+		},
+	})
+}
+
+func TestAccChapterDeletedOutsideTerraform(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	client := dataminded_api.NewClient(connection)
+	r := ChapterResource{}
+
+	var id int
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   r.chapter_basic(connection, data.RandomString),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.TestCheckResourceAttrWith("dataminded_chapter.test", "id", func(value string) (err error) {
+					id, err = strconv.Atoi(value)
+					return err
+				}),
+			},
+			{
+				PreConfig: func() {
+					if err := client.DeleteChapter(t.Context(), id); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:                   r.chapter_basic(connection, data.RandomString),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				PlanOnly:                 true,
+				ExpectNonEmptyPlan:       true,
+			},
+		},
+	})
+}
+
+func TestAccChapterRenamedOutsideTerraform(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	client := dataminded_api.NewClient(connection)
+	r := ChapterResource{}
+
+	var id int
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   r.chapter_basic(connection, data.RandomString),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.TestCheckResourceAttrWith("dataminded_chapter.test", "id", func(value string) (err error) {
+					id, err = strconv.Atoi(value)
+					return err
+				}),
+			},
+			{
+				PreConfig: func() {
+					if _, err := client.UpdateChapter(t.Context(), id, "renamed_"+data.RandomString); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:                   r.chapter_basic(connection, data.RandomString),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				PlanOnly:                 true,
+				ExpectNonEmptyPlan:       true,
+			},
+			{
+				Config:                   r.chapter_basic(connection, data.RandomString),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dataminded_chapter.test", "name", fmt.Sprintf("test_%s", data.RandomString)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccImportChapter(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	r := ChapterResource{}
+
+	acceptance.ImportTest(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: r.chapter_basic(connection, data.RandomString),
+			},
+			{
+				Config:            r.chapter_basic(connection, data.RandomString),
+				ResourceName:      "dataminded_chapter.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:          r.chapter_basic(connection, data.RandomString),
+				ResourceName:    "dataminded_chapter.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			{
+				Config:        r.chapter_basic(connection, data.RandomString),
+				ResourceName:  "dataminded_chapter.test",
+				ImportState:   true,
+				ImportStateId: "chapter_{}",
+				ExpectError:   regexp.MustCompile(`Expected the numeric id of the chapter`),
+			},
+			{
+				Config:            r.chapter_basic(connection, data.RandomString),
+				ResourceName:      "dataminded_chapter.test",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("name:test_%s", data.RandomString),
+				ImportStateVerify: true,
+			},
+			{
+				Config:        r.chapter_basic(connection, data.RandomString),
+				ResourceName:  "dataminded_chapter.test",
+				ImportState:   true,
+				ImportStateId: fmt.Sprintf("name:missing_%s", data.RandomString),
+				ExpectError:   regexp.MustCompile(`no chapter is named\s+"missing_`),
+			},
+		},
+	})
+}
+
+func (r ChapterResource) chapter_basic(connection dataminded_api.Connection, name string) string {
 	template := r.template(connection)
 
 	return fmt.Sprintf(
@@ -38,15 +198,15 @@ func (r ChapterResource) chapter_basic(connection dataminded_api.Connection) str
 		%[1]s
 
 		resource "dataminded_chapter" "test" {
+			name = "test_%[2]s"
 		}
-		`, template)
+		`, template, name)
 }
 
 func (r ChapterResource) template(connection dataminded_api.Connection) string {
 	return fmt.Sprintf(`
 		provider "dataminded" {
-			host = "%s"
-			port = %d
+			endpoint = "%s"
 		}
-	`, connection.Host, connection.Port)
+	`, connection.Endpoint)
 }
@@ -0,0 +1,169 @@
+package chapter
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"terraform-provider-dataminded/internal/dataminded_api"
+	"terraform-provider-dataminded/internal/logging"
+	"terraform-provider-dataminded/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &ChaptersDataSource{}
+	_ datasource.DataSourceWithConfigure = &ChaptersDataSource{}
+)
+
+func NewChaptersDataSource() datasource.DataSource {
+	return &ChaptersDataSource{}
+}
+
+type ChaptersDataSource struct {
+	Client *dataminded_api.Client
+}
+
+func (d *ChaptersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_chapters"
+}
+
+func (d *ChaptersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "List Dataminded chapters and their members, optionally filtered by name. A chapter is listed when it passes every filter that is set.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Regular expression, in Go syntax, that the name of a chapter must match somewhere.",
+				Validators: []validator.String{
+					validators.Regex(),
+				},
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Prefix the name of a chapter must start with.",
+			},
+			"chapters": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Chapters passing the filters, sorted by name and then id.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Id of the chapter in the sqlite database.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the chapter",
+						},
+						"leads":        membersAttribute("Members with the Lead role, sorted by name."),
+						"contributors": membersAttribute("Members with the Contributor role, sorted by name."),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ChaptersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = logging.WithDiagnostics(ctx, &resp.Diagnostics)
+
+	var config ChaptersDataSourceModel
+
+	// Read input configured in data block
+	resp.Diagnostics.Append(
+		req.Config.Get(ctx, &config)...,
+	)
+
+	if logging.HasError(ctx) {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !config.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(config.NameRegex.ValueString())
+
+		if err != nil {
+			logging.AddError(ctx, "Reading chapters failed", err)
+			return
+		}
+	}
+
+	chapters, err := d.Client.ListChapters(ctx)
+
+	if err != nil {
+		logging.AddError(ctx, "Reading chapters failed", err)
+		return
+	}
+
+	var listed []dataminded_api.Chapter
+	for _, chapter := range chapters {
+		if nameRegex != nil && !nameRegex.MatchString(chapter.Name) {
+			continue
+		}
+		if !config.NamePrefix.IsNull() && !strings.HasPrefix(chapter.Name, config.NamePrefix.ValueString()) {
+			continue
+		}
+		listed = append(listed, chapter)
+	}
+
+	sort.Slice(listed, func(i, j int) bool {
+		if listed[i].Name != listed[j].Name {
+			return listed[i].Name < listed[j].Name
+		}
+		return listed[i].Id < listed[j].Id
+	})
+
+	config.Chapters = []ChapterDataSourceModel{}
+
+	if len(listed) > 0 {
+		// One request for the members of every chapter rather than one per chapter
+		members, err := d.Client.ListAllChapterMembers(ctx)
+
+		if err != nil {
+			logging.AddError(ctx, "Reading chapter members failed", err)
+			return
+		}
+
+		users, err := d.Client.ListUsers(ctx)
+
+		if err != nil {
+			logging.AddError(ctx, "Reading chapter members failed", err)
+			return
+		}
+
+		for _, chapter := range listed {
+			config.Chapters = append(config.Chapters, chapterModel(chapter, members, users))
+		}
+	}
+
+	diags := resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *ChaptersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*dataminded_api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Configure Type",
+			fmt.Sprintf("Expected *dataminded_api.Client got: %T.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.Client = client
+}
@@ -1,4 +1,29 @@
 package chapter
 
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
 type ChapterResourceModel struct {
+	Id   types.Int64  `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+type ChapterDataSourceModel struct {
+	Id           types.Int64          `tfsdk:"id"`
+	Name         types.String         `tfsdk:"name"`
+	Leads        []ChapterMemberModel `tfsdk:"leads"`
+	Contributors []ChapterMemberModel `tfsdk:"contributors"`
+}
+
+type ChaptersDataSourceModel struct {
+	NameRegex  types.String             `tfsdk:"name_regex"`
+	NamePrefix types.String             `tfsdk:"name_prefix"`
+	Chapters   []ChapterDataSourceModel `tfsdk:"chapters"`
+}
+
+// ChapterMemberModel is a user that is a member of a chapter.
+type ChapterMemberModel struct {
+	Id   types.Int64  `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
 }
@@ -2,19 +2,31 @@ package chapter_member
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"terraform-provider-dataminded/internal/dataminded_api"
 	"terraform-provider-dataminded/internal/logging"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &ChapterMemberResource{}
-	_ resource.ResourceWithConfigure = &ChapterMemberResource{}
+	_ resource.Resource                = &ChapterMemberResource{}
+	_ resource.ResourceWithConfigure   = &ChapterMemberResource{}
+	_ resource.ResourceWithImportState = &ChapterMemberResource{}
 )
 
 func NewChapterMemberResource() resource.Resource {
@@ -22,7 +34,7 @@ func NewChapterMemberResource() resource.Resource {
 }
 
 type ChapterMemberResource struct {
-	Connection dataminded_api.Connection
+	Client *dataminded_api.Client
 }
 
 func (r *ChapterMemberResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -32,7 +44,38 @@ func (r *ChapterMemberResource) Metadata(_ context.Context, req resource.Metadat
 func (r *ChapterMemberResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manage Dataminded chapter members",
-		Attributes:  map[string]schema.Attribute{},
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Id of the membership, as `<chapter>/<member>`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"chapter": schema.Int64Attribute{
+				Required:    true,
+				Description: "Id of the chapter",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"member": schema.Int64Attribute{
+				Required:    true,
+				Description: "Id of the user that is a member of the chapter",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(dataminded_api.RoleContributor),
+				Description: "Role of the member in the chapter, either `Lead` or `Contributor`. Defaults to `Contributor`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(dataminded_api.Roles...),
+				},
+			},
+		},
 	}
 }
 
@@ -45,7 +88,19 @@ func (r *ChapterMemberResource) Create(ctx context.Context, req resource.CreateR
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	// TODO: use plan to create the chapter_member, and return result to the state
+
+	chapterId := int(plan.Chapter.ValueInt64())
+	userId := int(plan.Member.ValueInt64())
+
+	err := r.Client.CreateChapterMember(ctx, chapterId, userId, plan.Role.ValueString())
+
+	if err != nil {
+		logging.AddError(ctx, "Chapter member creation failed", err)
+		return
+	}
+
+	// Chapter member creation successful --> Set state of computed variables (Id)
+	plan.Id = types.StringValue(chapterMemberId(chapterId, userId))
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -65,8 +120,21 @@ func (r *ChapterMemberResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
-	// Use the state to read the chapter_member from the API
-	// If the chapter_member is not found, mark it for deletion
+	member, err := r.Client.ReadChapterMember(ctx, int(state.Chapter.ValueInt64()), int(state.Member.ValueInt64()))
+
+	if errors.Is(err, dataminded_api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		logging.AddError(ctx, "Reading chapter member failed", err)
+		return
+	}
+
+	// Set the read values
+	// We don't have to set chapter and member since these values were used to read
+	state.Role = types.StringValue(member.Role)
 
 	diags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -89,7 +157,15 @@ func (r *ChapterMemberResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
-	// Use the plan to update the chapter_member
+	// Only the role can change in place, a new chapter or member replaces the membership
+	err := r.Client.UpdateChapterMember(ctx, int(state.Chapter.ValueInt64()), int(state.Member.ValueInt64()), plan.Role.ValueString())
+
+	if err != nil {
+		logging.AddError(ctx, "Updating chapter member failed", err)
+		return
+	}
+
+	plan.Id = state.Id
 
 	diags := resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -108,8 +184,92 @@ func (r *ChapterMemberResource) Delete(ctx context.Context, req resource.DeleteR
 	if logging.HasError(ctx) {
 		return
 	}
-	// Use the state to delete the chapter_member
 
+	err := r.Client.DeleteChapterMember(ctx, int(state.Chapter.ValueInt64()), int(state.Member.ValueInt64()))
+
+	// A membership that is already gone needs no dropping
+	if err != nil && !errors.Is(err, dataminded_api.ErrNotFound) {
+		logging.AddError(ctx, "Dropping chapter member failed", err)
+	}
+}
+
+func chapterMemberId(chapterId int, userId int) string {
+	return fmt.Sprintf("%d/%d", chapterId, userId)
+}
+
+// splitChapterMemberId splits an import id of the form <chapter>/<member>. A
+// member given as user:<name> is split off first, so chapter names may contain
+// slashes.
+func splitChapterMemberId(id string) (string, string, error) {
+	if index := strings.Index(id, "/user:"); index >= 0 {
+		return id[:index], id[index+1:], nil
+	}
+
+	chapter, member, found := strings.Cut(id, "/")
+	if !found {
+		return "", "", fmt.Errorf("expected <chapter_id>/<user_id>, such as 1/2 or chapter:platform/user:Jelle, got: %q", id)
+	}
+
+	return chapter, member, nil
+}
+
+// resolveChapter returns the id of a chapter given as a number or as chapter:<name>.
+func (r *ChapterMemberResource) resolveChapter(ctx context.Context, chapter string) (int, error) {
+	if name, found := strings.CutPrefix(chapter, "chapter:"); found {
+		return r.Client.ChapterIdByName(ctx, name)
+	}
+
+	chapterId, err := strconv.Atoi(chapter)
+	if err != nil {
+		return 0, fmt.Errorf("expected a numeric chapter id or chapter:<name> before the slash, got: %q", chapter)
+	}
+
+	return chapterId, nil
+}
+
+// resolveUser returns the id of a user given as a number or as user:<name>.
+func (r *ChapterMemberResource) resolveUser(ctx context.Context, member string) (int, error) {
+	if name, found := strings.CutPrefix(member, "user:"); found {
+		return r.Client.UserIdByName(ctx, name)
+	}
+
+	userId, err := strconv.Atoi(member)
+	if err != nil {
+		return 0, fmt.Errorf("expected a numeric user id or user:<name> after the slash, got: %q", member)
+	}
+
+	return userId, nil
+}
+
+// ImportState adopts an existing membership by its <chapter>/<member> id, where
+// either side may be a name instead, Read fills in the role.
+func (r *ChapterMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = logging.WithDiagnostics(ctx, &resp.Diagnostics)
+
+	chapter, member, err := splitChapterMemberId(req.ID)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import id", fmt.Sprintf("Importing a chapter member failed: %s.", err))
+		return
+	}
+
+	chapterId, err := r.resolveChapter(ctx, chapter)
+
+	if err != nil {
+		logging.AddError(ctx, "Importing chapter member failed", err)
+		return
+	}
+
+	userId, err := r.resolveUser(ctx, member)
+
+	if err != nil {
+		logging.AddError(ctx, "Importing chapter member failed", err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), chapterMemberId(chapterId, userId))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("chapter"), int64(chapterId))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("member"), int64(userId))...)
 }
 
 func (r *ChapterMemberResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -118,16 +278,16 @@ func (r *ChapterMemberResource) Configure(_ context.Context, req resource.Config
 		return
 	}
 
-	connection, ok := req.ProviderData.(*dataminded_api.Connection)
+	client, ok := req.ProviderData.(*dataminded_api.Client)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Configure Type",
-			fmt.Sprintf("Expected *dataminded_api.Connection got: %T.", req.ProviderData),
+			fmt.Sprintf("Expected *dataminded_api.Client got: %T.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.Connection = *connection
+	r.Client = client
 }
@@ -2,12 +2,15 @@ package chapter_member_test
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"testing"
 
 	"terraform-provider-dataminded/internal/acceptance"
 	"terraform-provider-dataminded/internal/dataminded_api"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 type ChapterMemberResource struct{}
@@ -15,38 +18,278 @@ type ChapterMemberResource struct{}
 func TestAccCreateChapterMember(t *testing.T) {
 	data := acceptance.BuildTestData(t)
 	connection := dataminded_api.Connection{
-		Host: data.Host,
-		Port: data.Port,
+		Endpoint: data.Endpoint,
 	}
 	r := ChapterMemberResource{}
 
 	resource.Test(t, resource.TestCase{
 		Steps: []resource.TestStep{
 			{
-				Config:                   r.chapter_member_basic(connection),
+				Config:                   r.chapter_member_basic(connection, data.RandomString),
 				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dataminded_chapter_member.test", "role", "Contributor"),
+					resource.TestCheckResourceAttrPair("dataminded_chapter_member.test", "chapter", "dataminded_chapter.test", "id"),
+					resource.TestCheckResourceAttrPair("dataminded_chapter_member.test", "member", "dataminded_user.test", "id"),
+				),
 			},
 		},
 	})
 }
 
-func (r ChapterMemberResource) chapter_member_basic(connection dataminded_api.Connection) string {
-	template := r.template(connection)
+func TestAccUpdateChapterMemberRole(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	r := ChapterMemberResource{}
+
+	var id string
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   r.chapter_member_role(connection, data.RandomString, "Contributor"),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.TestCheckResourceAttrWith("dataminded_chapter_member.test", "id", func(value string) error {
+					id = value
+					return nil
+				}),
+			},
+			{
+				Config:                   r.chapter_member_role(connection, data.RandomString, "Lead"),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dataminded_chapter_member.test", "role", "Lead"),
+					// The role changes in place rather than replacing the membership
+					resource.TestCheckResourceAttrPtr("dataminded_chapter_member.test", "id", &id),
+				),
+			},
+			{
+				// Dropping the role returns the member to the default
+				Config:                   r.chapter_member_basic(connection, data.RandomString),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dataminded_chapter_member.test", "role", "Contributor"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInvalidChapterMemberRole(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	r := ChapterMemberResource{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   r.chapter_member_role(connection, data.RandomString, "lead"),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ExpectError:              regexp.MustCompile(`(?s)Invalid Attribute Value Match.*"Lead"`),
+			},
+		},
+	})
+}
+
+func TestAccChapterMemberRemovedOutsideTerraform(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	client := dataminded_api.NewClient(connection)
+	r := ChapterMemberResource{}
+
+	var chapterId, userId int
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   r.chapter_member_basic(connection, data.RandomString),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check:                    r.membership(&chapterId, &userId),
+			},
+			{
+				PreConfig: func() {
+					if err := client.DeleteChapterMember(t.Context(), chapterId, userId); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:                   r.chapter_member_basic(connection, data.RandomString),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				PlanOnly:                 true,
+				ExpectNonEmptyPlan:       true,
+			},
+		},
+	})
+}
+
+func TestAccChapterMemberRoleChangedOutsideTerraform(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	client := dataminded_api.NewClient(connection)
+	r := ChapterMemberResource{}
+
+	var chapterId, userId int
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   r.chapter_member_basic(connection, data.RandomString),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check:                    r.membership(&chapterId, &userId),
+			},
+			{
+				PreConfig: func() {
+					if err := client.UpdateChapterMember(t.Context(), chapterId, userId, dataminded_api.RoleLead); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:                   r.chapter_member_basic(connection, data.RandomString),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				PlanOnly:                 true,
+				ExpectNonEmptyPlan:       true,
+			},
+			{
+				Config:                   r.chapter_member_basic(connection, data.RandomString),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("dataminded_chapter_member.test", "role", "Contributor"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccImportChapterMember(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	r := ChapterMemberResource{}
+
+	acceptance.ImportTest(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: r.chapter_member_role(connection, data.RandomString, "Lead"),
+			},
+			{
+				Config:            r.chapter_member_role(connection, data.RandomString, "Lead"),
+				ResourceName:      "dataminded_chapter_member.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:          r.chapter_member_role(connection, data.RandomString, "Lead"),
+				ResourceName:    "dataminded_chapter_member.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			{
+				Config:            r.chapter_member_role(connection, data.RandomString, "Lead"),
+				ResourceName:      "dataminded_chapter_member.test",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("chapter:test_%[1]s/user:test_%[1]s", data.RandomString),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccImportChapterMemberMalformedId(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	r := ChapterMemberResource{}
+
+	steps := []resource.TestStep{
+		{
+			Config: r.chapter_member_basic(connection, data.RandomString),
+		},
+	}
+
+	for id, message := range map[string]string{
+		"1":              `expected <chapter_id>/<user_id>`,
+		"a/2":            `expected a numeric chapter id`,
+		"1/b":            `expected a numeric user id`,
+		"1/2/":           `expected a numeric user id`,
+		"1/user:nobody_": `no user is named`,
+	} {
+		steps = append(steps, resource.TestStep{
+			Config:        r.chapter_member_basic(connection, data.RandomString),
+			ResourceName:  "dataminded_chapter_member.test",
+			ImportState:   true,
+			ImportStateId: id,
+			ExpectError:   regexp.MustCompile(message),
+		})
+	}
+
+	acceptance.ImportTest(t, resource.TestCase{
+		Steps: steps,
+	})
+}
+
+// membership captures the chapter and member of the membership under test.
+func (r ChapterMemberResource) membership(chapterId *int, userId *int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		member := s.RootModule().Resources["dataminded_chapter_member.test"]
+		if member == nil {
+			return fmt.Errorf("dataminded_chapter_member.test not found in state")
+		}
+
+		var err error
+		if *chapterId, err = strconv.Atoi(member.Primary.Attributes["chapter"]); err != nil {
+			return err
+		}
+		*userId, err = strconv.Atoi(member.Primary.Attributes["member"])
+		return err
+	}
+}
+
+func (r ChapterMemberResource) chapter_member_basic(connection dataminded_api.Connection, name string) string {
+	template := r.template(connection, name)
 
 	return fmt.Sprintf(
 		`
 		%[1]s
 
 		resource "dataminded_chapter_member" "test" {
+			chapter = dataminded_chapter.test.id
+			member  = dataminded_user.test.id
 		}
 		`, template)
 }
 
-func (r ChapterMemberResource) template(connection dataminded_api.Connection) string {
+func (r ChapterMemberResource) chapter_member_role(connection dataminded_api.Connection, name string, role string) string {
+	template := r.template(connection, name)
+
+	return fmt.Sprintf(
+		`
+		%[1]s
+
+		resource "dataminded_chapter_member" "test" {
+			chapter = dataminded_chapter.test.id
+			member  = dataminded_user.test.id
+			role    = "%[2]s"
+		}
+		`, template, role)
+}
+
+func (r ChapterMemberResource) template(connection dataminded_api.Connection, name string) string {
 	return fmt.Sprintf(`
 		provider "dataminded" {
-			host = "%s"
-			port = %d
+			endpoint = "%[1]s"
+		}
+
+		resource "dataminded_chapter" "test" {
+			name = "test_%[2]s"
+		}
+
+		resource "dataminded_user" "test" {
+			name = "test_%[2]s"
 		}
-	`, connection.Host, connection.Port)
+	`, connection.Endpoint, name)
 }
@@ -0,0 +1,169 @@
+package functions
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ function.Function = ConfigDiff{}
+)
+
+func NewConfigDiff() function.Function {
+	return ConfigDiff{}
+}
+
+type ConfigDiff struct{}
+
+func (r ConfigDiff) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "chapter_config_diff"
+}
+
+func (r ConfigDiff) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Compare chapter configurations",
+		MarkdownDescription: "Compare two chapter configurations and return what changed: `added_members` and `removed_members` " +
+			"as `chapter_config_parser` returns them, `role_changes` with the `old_role` and `new_role`, and the names of `new_chapters` and " +
+			"`deleted_chapters`. The members of a new chapter are added members, and those of a deleted chapter removed members. " +
+			"Every list is sorted by chapter and then name, and empty rather than null when nothing changed. " +
+			"The configurations must be self-contained, as only `chapter_config_roster` resolves `include`: render what it returns " +
+			"with `chapter_config_render` to merge or compare a roster split over several files.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "old",
+				MarkdownDescription: "The chapter configuration before the change, in any format `chapter_config_parser` accepts.",
+			},
+			function.StringParameter{
+				Name:                "new",
+				MarkdownDescription: "The chapter configuration after the change, in any format `chapter_config_parser` accepts.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: configDiffAttributeTypes,
+		},
+	}
+}
+
+func (r ConfigDiff) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var oldData, newData string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &oldData, &newData))
+
+	if resp.Error != nil {
+		return
+	}
+
+	oldConfig, err := parseChapterConfig(oldData, "")
+
+	if err != nil {
+		resp.Error = configFuncError(0, err)
+		return
+	}
+
+	newConfig, err := parseChapterConfig(newData, "")
+
+	if err != nil {
+		resp.Error = configFuncError(1, err)
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, oldConfig.diff(newConfig)))
+}
+
+// configDiffAttributeTypes are the attributes of the object returned by the diff.
+var configDiffAttributeTypes = map[string]attr.Type{
+	"added_members":    types.ListType{ElemType: types.ObjectType{AttrTypes: membershipAttributeTypes}},
+	"removed_members":  types.ListType{ElemType: types.ObjectType{AttrTypes: membershipAttributeTypes}},
+	"role_changes":     types.ListType{ElemType: types.ObjectType{AttrTypes: roleChangeAttributeTypes}},
+	"new_chapters":     types.ListType{ElemType: types.StringType},
+	"deleted_chapters": types.ListType{ElemType: types.StringType},
+}
+
+var roleChangeAttributeTypes = map[string]attr.Type{
+	"chapter":  types.StringType,
+	"name":     types.StringType,
+	"old_role": types.StringType,
+	"new_role": types.StringType,
+}
+
+// configDiff is what changed between two chapter configurations.
+type configDiff struct {
+	AddedMembers    []membership `tfsdk:"added_members"`
+	RemovedMembers  []membership `tfsdk:"removed_members"`
+	RoleChanges     []roleChange `tfsdk:"role_changes"`
+	NewChapters     []string     `tfsdk:"new_chapters"`
+	DeletedChapters []string     `tfsdk:"deleted_chapters"`
+}
+
+// roleChange is a member that stays in a chapter with another role.
+type roleChange struct {
+	Chapter string `tfsdk:"chapter"`
+	Name    string `tfsdk:"name"`
+	OldRole string `tfsdk:"old_role"`
+	NewRole string `tfsdk:"new_role"`
+}
+
+// diff returns what changed from c to newer, sorted by chapter and then name.
+func (c ChapterConfig) diff(newer ChapterConfig) configDiff {
+	// Empty lists rather than null, so that length() works on every one of them
+	result := configDiff{
+		AddedMembers:    []membership{},
+		RemovedMembers:  []membership{},
+		RoleChanges:     []roleChange{},
+		NewChapters:     []string{},
+		DeletedChapters: []string{},
+	}
+
+	chapters := maps.Clone(c)
+	maps.Copy(chapters, newer)
+
+	for _, chapter := range slices.Sorted(maps.Keys(chapters)) {
+		oldMembers, existed := c.chapterMemberships(chapter)
+		newMembers, exists := newer.chapterMemberships(chapter)
+
+		switch {
+		case !existed:
+			result.NewChapters = append(result.NewChapters, chapter)
+		case !exists:
+			result.DeletedChapters = append(result.DeletedChapters, chapter)
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(oldMembers)) {
+			oldMember := oldMembers[name]
+			newMember, found := newMembers[name]
+
+			switch {
+			case !found:
+				result.RemovedMembers = append(result.RemovedMembers, oldMember)
+			case newMember.Role != oldMember.Role:
+				result.RoleChanges = append(result.RoleChanges, roleChange{Chapter: chapter, Name: name, OldRole: oldMember.Role, NewRole: newMember.Role})
+			}
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(newMembers)) {
+			if _, found := oldMembers[name]; !found {
+				result.AddedMembers = append(result.AddedMembers, newMembers[name])
+			}
+		}
+	}
+
+	return result
+}
+
+// chapterMemberships returns the memberships of the chapter with name by the name
+// of their member, and whether c has the chapter at all.
+func (c ChapterConfig) chapterMemberships(name string) (map[string]membership, bool) {
+	chapter, found := c[name]
+
+	result := map[string]membership{}
+	for _, member := range chapter.Members {
+		result[member.Name] = chapter.membership(name, member)
+	}
+
+	return result, found
+}
@@ -0,0 +1,165 @@
+package functions_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"terraform-provider-dataminded/internal/acceptance"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+)
+
+type ConfigDiff struct{}
+
+func roleChange(chapter string, name string, oldRole string, newRole string) knownvalue.Check {
+	return knownvalue.ObjectExact(map[string]knownvalue.Check{
+		"chapter":  knownvalue.StringExact(chapter),
+		"name":     knownvalue.StringExact(name),
+		"old_role": knownvalue.StringExact(oldRole),
+		"new_role": knownvalue.StringExact(newRole),
+	})
+}
+
+func TestAccChapterConfigDiff(t *testing.T) {
+	f := ConfigDiff{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: f.diff(
+					`
+					analytics:
+					  - name: Michiel
+					    role: Lead
+					  - name: Wouter
+					  - name: Kris
+					platform:
+					  - name: Jonathan
+					`,
+					`
+					analytics:
+					  - name: Wouter
+					    role: Lead
+					  - name: Michiel
+					  - name: Pascal
+					data:
+					  - name: Kris
+					    role: Lead
+					`,
+				),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						// The members of a new chapter are added, those of a deleted chapter removed
+						"added_members": knownvalue.ListExact([]knownvalue.Check{
+							membership("analytics", "Pascal", "Contributor"),
+							membership("data", "Kris", "Lead"),
+						}),
+						"removed_members": knownvalue.ListExact([]knownvalue.Check{
+							membership("analytics", "Kris", "Contributor"),
+							membership("platform", "Jonathan", "Contributor"),
+						}),
+						"role_changes": knownvalue.ListExact([]knownvalue.Check{
+							roleChange("analytics", "Michiel", "Lead", "Contributor"),
+							roleChange("analytics", "Wouter", "Contributor", "Lead"),
+						}),
+						"new_chapters":     knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact("data")}),
+						"deleted_chapters": knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact("platform")}),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccChapterConfigDiffUnchanged(t *testing.T) {
+	f := ConfigDiff{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				// Neither the format, the order nor spelling out the default role is a change
+				Config: f.diff(
+					`
+					analytics:
+					  - name: Michiel
+					    role: Lead
+					  - name: Wouter
+					`,
+					`{"analytics": [{"name": "Wouter", "role": "Contributor"}, {"name": "Michiel", "role": "Lead"}]}`,
+				),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"added_members":    knownvalue.ListSizeExact(0),
+						"removed_members":  knownvalue.ListSizeExact(0),
+						"role_changes":     knownvalue.ListSizeExact(0),
+						"new_chapters":     knownvalue.ListSizeExact(0),
+						"deleted_chapters": knownvalue.ListSizeExact(0),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccChapterConfigDiffInvalid(t *testing.T) {
+	f := ConfigDiff{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: f.diff(
+					`
+					analytics:
+					  - name: Michiel
+					`,
+					`
+					analytics:
+					  - name: Michiel
+					    role: lead
+					`,
+				),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ExpectError:              regexp.MustCompile(`(?s)Invalid value for "new" parameter.*line 3, column 11:\s+role\s+"lead"`),
+			},
+		},
+	})
+}
+
+func TestAccChapterConfigDiffInclude(t *testing.T) {
+	f := ConfigDiff{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				// Only self-contained configurations can be compared
+				Config: f.diff(
+					`
+					version: 2
+					include: [teams.yaml]
+					`,
+					`
+					analytics:
+					  - name: Michiel
+					`,
+				),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ExpectError:              regexp.MustCompile(`(?s)Invalid value for "old" parameter.*line 2, column 11:\s+"teams.yaml"\s+cannot\s+be\s+included`),
+			},
+		},
+	})
+}
+
+// diff calls the function on oldInput and newInput, dedented so that tests can indent
+// their YAML.
+func (f ConfigDiff) diff(oldInput string, newInput string) string {
+	return fmt.Sprintf(`
+		output "test" {
+			value = provider::dataminded::chapter_config_diff(%q, %q)
+		}
+	`, dedent(oldInput), dedent(newInput))
+}
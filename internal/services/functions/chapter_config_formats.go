@@ -0,0 +1,225 @@
+package functions
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"terraform-provider-dataminded/internal/dataminded_api"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// The formats a chapter configuration can be written in.
+const (
+	formatYAML = "yaml"
+	formatJSON = "json"
+	formatTOML = "toml"
+)
+
+var formats = []string{formatYAML, formatJSON, formatTOML}
+
+// tomlStart matches the first line of a TOML document: a table header such as
+// [[analytics]], or a key/value pair such as analytics = [].
+var tomlStart = regexp.MustCompile(`^(\[\[?\s*[^\[\]]+\]\]?|[A-Za-z0-9_."'-]+\s*=)`)
+
+// detectFormat guesses the format of data from its first line that is neither blank
+// nor a comment. Anything that is neither JSON nor TOML is taken to be YAML.
+func detectFormat(data string) string {
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "{") {
+			return formatJSON
+		}
+
+		if tomlStart.MatchString(line) {
+			return formatTOML
+		}
+
+		return formatYAML
+	}
+
+	return formatYAML
+}
+
+// decodeDetected decodes data in the format detectFormat guesses. A YAML flow
+// mapping starts with { as well, so data that is not valid JSON is decoded as YAML,
+// and gives the JSON error when it is not valid YAML either.
+func decodeDetected(data string) (*yaml.Node, error) {
+	format := detectFormat(data)
+
+	document, err := decodeDocument(data, format)
+	if err != nil && format == formatJSON {
+		if document, yamlErr := decodeDocument(data, formatYAML); yamlErr == nil {
+			return document, nil
+		}
+	}
+
+	return document, err
+}
+
+// decodeDocument decodes data into a YAML document node, whatever its format, so
+// that every format is validated alike. YAML and JSON keep the position of their
+// values, TOML has none to offer.
+func decodeDocument(data string, format string) (*yaml.Node, error) {
+	var document yaml.Node
+
+	switch format {
+	case formatYAML:
+		if err := yaml.Unmarshal([]byte(data), &document); err != nil {
+			return nil, err
+		}
+
+	case formatJSON:
+		if strings.TrimSpace(data) == "" {
+			return &document, nil
+		}
+
+		// Checked first, as YAML would accept more than JSON does
+		var decoded any
+		if err := json.Unmarshal([]byte(data), &decoded); err != nil {
+			return nil, jsonError(data, err)
+		}
+
+		// JSON is YAML, and parsing it as such keeps the positions. The exception is
+		// a tab indenting a line, which JSON allows and YAML does not.
+		if err := yaml.Unmarshal([]byte(data), &document); err != nil {
+			return encodedDocument(decoded)
+		}
+
+	case formatTOML:
+		var decoded map[string]any
+		if _, err := toml.Decode(data, &decoded); err != nil {
+			return nil, err
+		}
+
+		if len(decoded) == 0 {
+			return &document, nil
+		}
+
+		return encodedDocument(decoded)
+
+	default:
+		return nil, fmt.Errorf("unknown format %q, expected one of %s", format, strings.Join(formats, ", "))
+	}
+
+	return &document, nil
+}
+
+// encodedDocument returns a document node for a decoded value. Its nodes have no
+// position.
+func encodedDocument(decoded any) (*yaml.Node, error) {
+	var root yaml.Node
+	if err := root.Encode(decoded); err != nil {
+		return nil, err
+	}
+
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{&root}}, nil
+}
+
+// canonicalYAML renders the config as YAML, with the chapters and their members
+// sorted by name. The role of every member is spelled out, unless omitDefaultRoles
+// leaves out the Contributor roles. It is a version 1 configuration unless a
+// chapter has a description or a member has aliases, which only version 2 can hold.
+func (c ChapterConfig) canonicalYAML(omitDefaultRoles bool) (string, error) {
+	version2 := false
+	for _, chapter := range c {
+		for _, member := range chapter.Members {
+			version2 = version2 || chapter.Description != "" || len(member.Aliases) > 0
+		}
+	}
+
+	chapters := &yaml.Node{Kind: yaml.MappingNode}
+
+	for _, name := range slices.Sorted(maps.Keys(c)) {
+		chapter := c[name]
+		members := slices.SortedFunc(slices.Values(chapter.Members), func(a ChapterMember, b ChapterMember) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+
+		list := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, member := range members {
+			node := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{scalar("name"), scalar(member.Name)}}
+			if role := chapter.role(member); !omitDefaultRoles || role != dataminded_api.RoleContributor {
+				node.Content = append(node.Content, scalar("role"), scalar(role))
+			}
+
+			if len(member.Aliases) > 0 {
+				aliases := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+				for _, alias := range member.Aliases {
+					aliases.Content = append(aliases.Content, scalar(alias))
+				}
+				node.Content = append(node.Content, scalar("aliases"), aliases)
+			}
+
+			list.Content = append(list.Content, node)
+		}
+
+		if !version2 {
+			chapters.Content = append(chapters.Content, scalar(name), list)
+			continue
+		}
+
+		// The roles are spelled out, so there is no default role to render
+		fields := &yaml.Node{Kind: yaml.MappingNode}
+		if chapter.Description != "" {
+			fields.Content = append(fields.Content, scalar("description"), scalar(chapter.Description))
+		}
+		fields.Content = append(fields.Content, scalar("members"), list)
+
+		chapters.Content = append(chapters.Content, scalar(name), fields)
+	}
+
+	root := chapters
+	if version2 {
+		root = &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+			scalar("version"), {Kind: yaml.ScalarNode, Tag: "!!int", Value: "2"},
+			scalar("chapters"), chapters,
+		}}
+	}
+
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(root); err != nil {
+		return "", err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
+
+// scalar returns a string node, quoted when it would otherwise read as another type.
+func scalar(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// jsonError adds the line and column to a JSON syntax error, which only knows its
+// offset.
+func jsonError(data string, err error) error {
+	var syntaxError *json.SyntaxError
+	if !errors.As(err, &syntaxError) {
+		return err
+	}
+
+	before := data[:syntaxError.Offset]
+	line := strings.Count(before, "\n") + 1
+	column := len(before) - strings.LastIndex(before, "\n")
+
+	return fmt.Errorf("json: line %d, column %d: %w", line, column, err)
+}
@@ -0,0 +1,191 @@
+package functions_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"terraform-provider-dataminded/internal/acceptance"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+)
+
+// golden checks that a JSON string equals the JSON in the golden file at path.
+func golden(t *testing.T, path string) knownvalue.Check {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expected any
+	if err := json.Unmarshal(content, &expected); err != nil {
+		t.Fatal(err)
+	}
+
+	return knownvalue.StringFunc(func(value string) error {
+		var actual any
+		if err := json.Unmarshal([]byte(value), &actual); err != nil {
+			return err
+		}
+
+		if !reflect.DeepEqual(expected, actual) {
+			return fmt.Errorf("expected the content of %s, got: %s", path, value)
+		}
+
+		return nil
+	})
+}
+
+func TestAccChapterConfigParserFormats(t *testing.T) {
+	f := ConfigParser{}
+
+	for _, format := range []string{"yaml", "json", "toml"} {
+		for _, explicit := range []bool{false, true} {
+			name := format + " detected"
+			arguments := fmt.Sprintf(`file(%q)`, testdata(t, "chapter_config."+format))
+			if explicit {
+				name = format + " given"
+				arguments += fmt.Sprintf(`, %q`, format)
+			}
+
+			t.Run(name, func(t *testing.T) {
+				resource.Test(t, resource.TestCase{
+					Steps: []resource.TestStep{
+						{
+							Config:                   f.parse_json(arguments),
+							ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+							ConfigStateChecks: []statecheck.StateCheck{
+								// Every encoding of the same roster gives the same memberships
+								statecheck.ExpectKnownOutputValue("test", golden(t, testdata(t, "chapter_config.golden.json"))),
+							},
+						},
+					},
+				})
+			})
+		}
+	}
+}
+
+func TestAccChapterConfigParserJsonIndentedWithTabs(t *testing.T) {
+	f := ConfigParser{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				// Valid JSON, but not valid YAML
+				Config:                   f.parse("{\n\t\"analytics\": [\n\t\t{\"name\": \"Michiel\"}\n\t]\n}"),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.MapExact(map[string]knownvalue.Check{
+						"analytics-Michiel": membership("analytics", "Michiel", "Contributor"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccChapterConfigParserYamlFlowMapping(t *testing.T) {
+	f := ConfigParser{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				// Starts like JSON, but is only valid as YAML
+				Config:                   f.parse(`{analytics: [{name: Michiel, role: Lead}]}`),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.MapExact(map[string]knownvalue.Check{
+						"analytics-Michiel": membership("analytics", "Michiel", "Lead"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccChapterConfigParserFormatErrors(t *testing.T) {
+	f := ConfigParser{}
+
+	tests := []struct {
+		name      string
+		arguments string
+		message   string
+	}{
+		{
+			name:      "unknown format",
+			arguments: `"analytics: []", "xml"`,
+			message:   `Unknown\s+format\s+"xml",\s+expected\s+one\s+of\s+yaml,\s+json,\s+toml`,
+		},
+		{
+			name:      "several formats",
+			arguments: `"analytics: []", "yaml", "json"`,
+			message:   `Expected\s+at\s+most\s+one\s+format,\s+got\s+2`,
+		},
+		{
+			name:      "yaml given as json",
+			arguments: `"analytics:\n  - name: Michiel\n", "json"`,
+			message:   `json:\s+line\s+1,\s+column\s+2`,
+		},
+		{
+			name:      "invalid json",
+			arguments: `"{\"analytics\": [}"`,
+			message:   `json:\s+line\s+1,\s+column\s+17`,
+		},
+		{
+			name:      "invalid toml",
+			arguments: `"[[analytics]]\nname = Michiel\n"`,
+			message:   `Parsing\s+the\s+chapter\s+configuration\s+failed:\s+toml:`,
+		},
+		{
+			name:      "invalid toml config",
+			arguments: `"[[analytics]]\nname = \"Michiel\"\nrole = \"lead\"\n"`,
+			message:   `role\s+"lead"\s+of\s+"Michiel"\s+in\s+chapter\s+"analytics"\s+is\s+not\s+one\s+of`,
+		},
+		{
+			name:      "invalid json config",
+			arguments: `"{\"analytics\": [{\"name\": \"Michiel\", \"role\": \"lead\"}]}"`,
+			message:   `line\s+1,\s+column\s+44:\s+role\s+"lead"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				Steps: []resource.TestStep{
+					{
+						Config:                   f.parse_json(test.arguments),
+						ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+						ExpectError:              regexp.MustCompile(test.message),
+					},
+				},
+			})
+		})
+	}
+}
+
+// testdata returns the absolute path of a file in testdata, as Terraform runs the
+// configuration from another directory.
+func testdata(t *testing.T, name string) string {
+	path, err := filepath.Abs(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+// parse_json calls the function with the HCL arguments and encodes the result as JSON.
+func (f ConfigParser) parse_json(arguments string) string {
+	return fmt.Sprintf(`
+		output "test" {
+			value = jsonencode(provider::dataminded::chapter_config_parser(%s))
+		}
+	`, arguments)
+}
@@ -0,0 +1,169 @@
+package functions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var (
+	_ function.Function = ConfigMerge{}
+)
+
+func NewConfigMerge() function.Function {
+	return ConfigMerge{}
+}
+
+type ConfigMerge struct{}
+
+func (r ConfigMerge) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "chapter_config_merge"
+}
+
+func (r ConfigMerge) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Merge chapter configurations",
+		MarkdownDescription: "Merge overlays into a base chapter configuration and return the result as canonical YAML, " +
+			"ready for `chapter_config_parser`. The overlays apply in order: their new chapters and members are added, " +
+			"a member already present takes the role and aliases the overlay gives it, and a member marked `remove: true` is removed. " +
+			"A chapter left without members is removed as well, and aliases that end up naming another member are an error. " +
+			"The configurations must be self-contained, as only `chapter_config_roster` resolves `include`: render what it returns " +
+			"with `chapter_config_render` to merge or compare a roster split over several files.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "base",
+				MarkdownDescription: "The base chapter configuration, in any format `chapter_config_parser` accepts.",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name: "overlays",
+			MarkdownDescription: "Chapter configurations to merge into the base, in any format `chapter_config_parser` accepts. " +
+				"Their members may be marked `remove: true`, a member without a role keeps the role it has.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (r ConfigMerge) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var data string
+	var overlays []string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &data, &overlays))
+
+	if resp.Error != nil {
+		return
+	}
+
+	config, err := parseChapterConfig(data, "")
+
+	if err != nil {
+		resp.Error = configFuncError(0, err)
+		return
+	}
+
+	for i, data := range overlays {
+		argument := int64(1 + i)
+
+		overlay, err := parseOverlay(data, "")
+
+		if err != nil {
+			resp.Error = configFuncError(argument, err)
+			return
+		}
+
+		config, err = config.merge(overlay)
+
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(argument, fmt.Sprintf("Merging overlay %d failed:\n%s", i+1, err))
+			return
+		}
+	}
+
+	merged, err := config.canonicalYAML(false)
+
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Rendering the merged chapter configuration failed: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, merged))
+}
+
+// merge returns c with overlay applied: new chapters and members are added, members
+// already in c take the role and aliases the overlay gives them, and members marked
+// remove are removed. A chapter left without members is removed as well. Removing a
+// member that is not there is an error, as it is most likely a typo. The default
+// role of a chapter in the overlay only applies to the members it adds.
+func (c ChapterConfig) merge(overlay ChapterConfig) (ChapterConfig, error) {
+	merged := ChapterConfig{}
+	for name, chapter := range c {
+		chapter.Members = slices.Clone(chapter.Members)
+		merged[name] = chapter
+	}
+
+	var problems []string
+
+	for _, name := range slices.Sorted(maps.Keys(overlay)) {
+		layer := overlay[name]
+
+		chapter, found := merged[name]
+		if !found {
+			chapter.DefaultRole = layer.DefaultRole
+		}
+		if layer.Description != "" {
+			chapter.Description = layer.Description
+		}
+
+		members := chapter.Members
+
+		for _, member := range layer.Members {
+			index := slices.IndexFunc(members, func(other ChapterMember) bool {
+				return other.Name == member.Name
+			})
+
+			switch {
+			case member.Remove && index < 0:
+				problems = append(problems, fmt.Sprintf("%q is not a member of chapter %q, and cannot be removed", member.Name, name))
+			case member.Remove:
+				members = slices.Delete(members, index, index+1)
+			case index < 0:
+				role := member.Role
+				if role == "" {
+					role = layer.DefaultRole
+				}
+				members = append(members, ChapterMember{Name: member.Name, Role: role, Aliases: member.Aliases})
+			default:
+				if member.Role != "" {
+					members[index].Role = member.Role
+				}
+				if member.Aliases != nil {
+					members[index].Aliases = member.Aliases
+				}
+			}
+		}
+
+		chapter.Members = members
+
+		// Aliases of the base and the overlay may clash once merged
+		for _, clash := range aliasClashes(members) {
+			problems = append(problems, clash.message(name))
+		}
+
+		if len(members) == 0 {
+			delete(merged, name)
+		} else {
+			merged[name] = chapter
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, errors.New(strings.Join(problems, "\n"))
+	}
+
+	return merged, nil
+}
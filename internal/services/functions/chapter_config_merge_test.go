@@ -0,0 +1,323 @@
+package functions_test
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"terraform-provider-dataminded/internal/acceptance"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+)
+
+type ConfigMerge struct{}
+
+func TestAccChapterConfigMerge(t *testing.T) {
+	f := ConfigMerge{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: f.merge(
+					`
+					platform:
+					  - name: Wouter
+					  - name: Jonathan
+					    role: Lead
+					analytics:
+					  - name: Michiel
+					    role: Lead
+					`,
+					// Promote Wouter, add Kris and a chapter of its own
+					`
+					platform:
+					  - name: Wouter
+					    role: Lead
+					  - name: Kris
+					data:
+					  - name: Kris
+					    role: Lead
+					`,
+					// Remove Michiel, which leaves analytics empty, and keep the role of Jonathan
+					`{"analytics": [{"name": "Michiel", "remove": true}], "platform": [{"name": "Jonathan"}]}`,
+				),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact(dedent(`
+						data:
+						  - name: Kris
+						    role: Lead
+						platform:
+						  - name: Jonathan
+						    role: Lead
+						  - name: Kris
+						    role: Contributor
+						  - name: Wouter
+						    role: Lead
+					`))),
+				},
+			},
+		},
+	})
+}
+
+func TestAccChapterConfigMergeVersion2(t *testing.T) {
+	f := ConfigMerge{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: f.merge(
+					`
+					version: 2
+					chapters:
+					  analytics:
+					    default_role: Lead
+					    members:
+					      - name: Michiel
+					`,
+					// The default role of the overlay applies to the members it adds, and
+					// a member keeps its aliases unless the overlay gives others
+					`
+					version: 2
+					chapters:
+					  analytics:
+					    description: Data analysis and reporting
+					    default_role: Contributor
+					    members:
+					      - name: Michiel
+					        aliases: [mich]
+					      - name: Wouter
+					`,
+					`
+					analytics:
+					  - name: Michiel
+					    role: Contributor
+					`,
+				),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact(dedent(`
+						version: 2
+						chapters:
+						  analytics:
+						    description: Data analysis and reporting
+						    members:
+						      - name: Michiel
+						        role: Contributor
+						        aliases: [mich]
+						      - name: Wouter
+						        role: Contributor
+					`))),
+				},
+			},
+		},
+	})
+}
+
+func TestAccChapterConfigMergeIntoRoster(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				// The aliases survive the merge, and the result parses
+				Config: fmt.Sprintf(`
+					output "test" {
+						value = provider::dataminded::chapter_config_roster(provider::dataminded::chapter_config_merge(%q, %q), {})
+					}
+				`, dedent(`
+					analytics:
+					  - name: Michiel
+					  - name: Wouter
+				`), dedent(`
+					version: 2
+					chapters:
+					  analytics:
+					    members:
+					      - name: Michiel
+					        aliases: [mich]
+					      - name: Wouter
+					        aliases: [wouter.v]
+				`)),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.MapExact(map[string]knownvalue.Check{
+						"analytics-Michiel": rosterMembership("analytics", "", "Michiel", "Contributor", "mich"),
+						"analytics-Wouter":  rosterMembership("analytics", "", "Wouter", "Contributor", "wouter.v"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccChapterConfigMergeIntoParser(t *testing.T) {
+	f := ConfigMerge{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					output "test" {
+						value = provider::dataminded::chapter_config_parser(provider::dataminded::chapter_config_merge(%q, %q))
+					}
+				`, dedent(`
+					analytics:
+					  - name: Michiel
+				`), dedent(`
+					analytics = [{ name = "Michiel", role = "Lead" }]
+				`)),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.MapExact(map[string]knownvalue.Check{
+						"analytics-Michiel": membership("analytics", "Michiel", "Lead"),
+					})),
+				},
+			},
+			{
+				// Without overlays the base comes out in canonical form
+				Config:                   f.merge(`{"analytics": [{"name": "Wouter"}, {"name": "Michiel"}]}`),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact(dedent(`
+						analytics:
+						  - name: Michiel
+						    role: Contributor
+						  - name: Wouter
+						    role: Contributor
+					`))),
+				},
+			},
+		},
+	})
+}
+
+func TestAccChapterConfigMergeInvalid(t *testing.T) {
+	f := ConfigMerge{}
+
+	tests := []struct {
+		name    string
+		inputs  []string
+		message string
+	}{
+		{
+			name: "remove in the base",
+			inputs: []string{`
+				analytics:
+				  - name: Michiel
+				    remove: true
+			`},
+			message: `line 3, column 5:\s+unknown\s+field\s+"remove"\s+in\s+chapter\s+"analytics",\s+a\s+member\s+has\s+a\s+name\s+and\s+role`,
+		},
+		{
+			name: "removing an absent member",
+			inputs: []string{
+				`
+				analytics:
+				  - name: Michiel
+				`,
+				`
+				analytics:
+				  - name: Wouter
+				    remove: true
+				platform:
+				  - name: Jonathan
+				    remove: true
+				`,
+			},
+			message: `(?s)Merging\s+overlay\s+1\s+failed.*` +
+				`"Wouter"\s+is\s+not\s+a\s+member\s+of\s+chapter\s+"analytics",\s+and\s+cannot\s+be\s+removed.*` +
+				`"Jonathan"\s+is\s+not\s+a\s+member\s+of\s+chapter\s+"platform"`,
+		},
+		{
+			name: "removing with a role",
+			inputs: []string{
+				`
+				analytics:
+				  - name: Michiel
+				`,
+				`
+				analytics:
+				  - name: Michiel
+				    role: Lead
+				    remove: true
+				`,
+			},
+			message: `"Michiel"\s+is\s+removed\s+from\s+chapter\s+"analytics",\s+and\s+cannot\s+have\s+a\s+role`,
+		},
+		{
+			name: "alias naming another member",
+			inputs: []string{
+				`
+				analytics:
+				  - name: Michiel
+				  - name: Wouter
+				`,
+				`
+				version: 2
+				chapters:
+				  analytics:
+				    members:
+				      - name: Michiel
+				        aliases: [Wouter]
+				`,
+			},
+			message: `(?s)Merging\s+overlay\s+1\s+failed.*alias\s+"Wouter"\s+of\s+"Michiel"\s+in\s+chapter\s+"analytics"\s+already\s+names\s+"Wouter"`,
+		},
+		{
+			name: "include",
+			inputs: []string{
+				`
+				version: 2
+				include: [teams.yaml]
+				`,
+			},
+			message: `line 2, column 11:\s+"teams.yaml"\s+cannot\s+be\s+included,\s+only\s+chapter_config_roster`,
+		},
+		{
+			name: "remove is not a boolean",
+			inputs: []string{
+				`
+				analytics:
+				  - name: Michiel
+				`,
+				`
+				analytics:
+				  - name: Michiel
+				    remove: maybe
+				`,
+			},
+			message: `line 3, column 13:\s+expected\s+remove\s+of\s+a\s+member\s+of\s+chapter\s+"analytics"\s+to\s+be\s+true\s+or\s+false`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				Steps: []resource.TestStep{
+					{
+						Config:                   f.merge(test.inputs...),
+						ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+						ExpectError:              regexp.MustCompile(test.message),
+					},
+				},
+			})
+		})
+	}
+}
+
+// merge calls the function on inputs, each dedented so that tests can indent their YAML.
+func (f ConfigMerge) merge(inputs ...string) string {
+	arguments := make([]string, len(inputs))
+	for i, input := range inputs {
+		arguments[i] = fmt.Sprintf("%q", dedent(input))
+	}
+
+	return fmt.Sprintf(`
+		output "test" {
+			value = provider::dataminded::chapter_config_merge(%s)
+		}
+	`, strings.Join(arguments, ", "))
+}
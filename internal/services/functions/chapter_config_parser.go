@@ -5,10 +5,18 @@ package functions
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
+	"terraform-provider-dataminded/internal/dataminded_api"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
 )
 
 var (
@@ -27,48 +35,672 @@ func (r ConfigParser) Metadata(_ context.Context, req function.MetadataRequest,
 
 func (r ConfigParser) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
 	resp.Definition = function.Definition{
-		Summary:             "Parse chapter configuration",
-		MarkdownDescription: "Parse chapter configuration and return the parsed data as an object.",
+		Summary: "Parse chapter configuration",
+		MarkdownDescription: "Parse chapter configuration and return its memberships, keyed `<chapter>-<name>` so the result " +
+			"can drive the `for_each` of `dataminded_chapter_member` directly. A member without a role takes the `default_role` " +
+			"of its chapter, or is a `Contributor`. Unknown roles, members listed twice, members without a name and chapters " +
+			"without members are reported all at once, each with its line and column unless the input is TOML.\n\n" +
+			"A configuration starting with `version: 2` is read as well, but its descriptions and aliases are left out, " +
+			"and it cannot `include` other configurations. Use `chapter_config_roster` for those.",
 		Parameters: []function.Parameter{
 			function.StringParameter{
-				Name:                "input",
-				MarkdownDescription: "The input data to parse.",
+				Name: "input",
+				MarkdownDescription: "The chapter configuration, mapping each chapter to a list of members with a `name` and an optional `role`. " +
+					"Written in YAML, JSON, or TOML with a table array per chapter.",
 			},
 		},
+		VariadicParameter: formatParameter,
 		Return: function.MapReturn{
-			ElementType: types.StringType,
+			ElementType: types.ObjectType{
+				AttrTypes: membershipAttributeTypes,
+			},
 		},
 	}
 }
 
+// formatParameter is the optional format of a chapter configuration.
+var formatParameter = function.StringParameter{
+	Name: "format",
+	MarkdownDescription: "The format of the input, one of `yaml`, `json` and `toml`. Without it the format is detected: " +
+		"input starting with `{` is JSON unless it only decodes as YAML, input starting with a table header or a key/value pair " +
+		"is TOML, and anything else is YAML.",
+}
+
+// formatArgument returns the format passed as the argument at position, or "" when
+// there is none.
+func formatArgument(ctx context.Context, req function.RunRequest, position int) (string, *function.FuncError) {
+	var format []string
+
+	if err := req.Arguments.GetArgument(ctx, position, &format); err != nil {
+		return "", err
+	}
+
+	switch {
+	case len(format) == 0:
+		return "", nil
+	case len(format) > 1:
+		return "", function.NewArgumentFuncError(int64(position+1), fmt.Sprintf("Expected at most one format, got %d", len(format)))
+	case !slices.Contains(formats, format[0]):
+		return "", function.NewArgumentFuncError(int64(position), fmt.Sprintf("Unknown format %q, expected one of %s", format[0], strings.Join(formats, ", ")))
+	}
+
+	return format[0], nil
+}
+
 func (r ConfigParser) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
 	var data string
 
-	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &data))
+	resp.Error = function.ConcatFuncErrors(req.Arguments.GetArgument(ctx, 0, &data))
 
 	if resp.Error != nil {
 		return
 	}
 
-	// This returns the arguments received via the req.Arguments.Get(ctx, &data) to the result. It's basically an echo function.
-	// The types defined below can help you parse a yaml file into Go objects.
-	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, data))
+	format, funcErr := formatArgument(ctx, req, 1)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	config, err := parseChapterConfig(data, format)
+
+	if err != nil {
+		resp.Error = configFuncError(0, err)
+		return
+	}
+
+	memberships, err := config.memberships()
+
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid chapter configuration: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, memberships))
+}
+
+// configFuncError reports why the chapter configuration passed as argument could
+// not be read.
+func configFuncError(argument int64, err error) *function.FuncError {
+	var invalid *configError
+	if errors.As(err, &invalid) {
+		return function.NewArgumentFuncError(argument, fmt.Sprintf("Invalid chapter configuration:\n%s", err))
+	}
+
+	return function.NewArgumentFuncError(argument, fmt.Sprintf("Parsing the chapter configuration failed: %s", err))
 }
 
 type ChapterMember struct {
-	Name string `yaml:"name"`
-	Role string `yaml:"role,omitempty"`
+	Name    string   `yaml:"name"`
+	Role    string   `yaml:"role,omitempty"`
+	Aliases []string `yaml:"aliases,omitempty"`
+	// Remove marks a member of an overlay that is to be removed by the merge.
+	Remove bool `yaml:"remove,omitempty"`
+}
+
+// Chapter is a chapter and its members. Only version 2 configurations give a
+// chapter a description and a default role.
+type Chapter struct {
+	Description string
+	DefaultRole string
+	Members     []ChapterMember
+}
+
+// role returns the role of member, which is the default role of the chapter when
+// it has none, and Contributor when the chapter has no default role either.
+func (c Chapter) role(member ChapterMember) string {
+	switch {
+	case member.Role != "":
+		return member.Role
+	case c.DefaultRole != "":
+		return c.DefaultRole
+	default:
+		return dataminded_api.RoleContributor
+	}
 }
 
-type ChapterConfig map[string][]ChapterMember
+type ChapterConfig map[string]Chapter
 
-func parseChapterConfig(data string) (ChapterConfig, error) { //nolint:unused
-	var parsedConfig ChapterConfig
+// membershipAttributeTypes are the attributes of a membership returned by the parser.
+var membershipAttributeTypes = map[string]attr.Type{
+	"chapter": types.StringType,
+	"name":    types.StringType,
+	"role":    types.StringType,
+}
+
+// membership is a member of one chapter, as returned by the parser.
+type membership struct {
+	Chapter string `tfsdk:"chapter"`
+	Name    string `tfsdk:"name"`
+	Role    string `tfsdk:"role"`
+}
+
+// membershipKey identifies a membership in the map returned by the parser.
+func membershipKey(chapter string, name string) string {
+	return fmt.Sprintf("%s-%s", chapter, name)
+}
+
+// memberships flattens the config into its memberships, keyed by membershipKey.
+func (c ChapterConfig) memberships() (map[string]membership, error) {
+	result := map[string]membership{}
+
+	for _, name := range slices.Sorted(maps.Keys(c)) {
+		chapter := c[name]
+		for _, member := range chapter.Members {
+			key := membershipKey(name, member.Name)
+			// Such as chapter "a-b" with member "c" and chapter "a" with member "b-c"
+			if other, found := result[key]; found {
+				return nil, fmt.Errorf("%q in chapter %q and %q in chapter %q share the key %q",
+					member.Name, name, other.Name, other.Chapter, key)
+			}
+
+			result[key] = chapter.membership(name, member)
+		}
+	}
+
+	return result, nil
+}
+
+// membership returns the membership of member in the chapter with name.
+func (c Chapter) membership(name string, member ChapterMember) membership {
+	return membership{
+		Chapter: name,
+		Name:    member.Name,
+		Role:    c.role(member),
+	}
+}
+
+// configProblem is a mistake in a chapter configuration, at the position where it
+// was made.
+type configProblem struct {
+	// file is the name of the included configuration, empty for the input itself
+	file    string
+	line    int
+	column  int
+	message string
+}
+
+// configError lists every problem found in a chapter configuration, in the order
+// of the input.
+type configError struct {
+	problems []configProblem
+}
+
+func (e *configError) Error() string {
+	lines := make([]string, 0, len(e.problems))
+	for _, problem := range e.problems {
+		line := problem.message
+		// Nodes decoded from TOML have no position
+		if problem.line != 0 {
+			line = fmt.Sprintf("line %d, column %d: %s", problem.line, problem.column, line)
+		}
+		if problem.file != "" {
+			line = fmt.Sprintf("%s: %s", problem.file, line)
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// configValidator collects the problems found while reading a chapter configuration.
+type configValidator struct {
+	// overlay allows members to be marked for removal
+	overlay bool
+	// version of the configuration being read, which allows aliases from 2 on
+	version int
+	// file is the name of the included configuration being read
+	file string
+	// includes are the configurations that can be included by name, nil when the
+	// configuration must be self-contained
+	includes map[string]string
+	// including are the included configurations being read, to catch a cycle
+	including []string
+	problems  []configProblem
+}
+
+func (v *configValidator) add(node *yaml.Node, format string, args ...any) {
+	v.problems = append(v.problems, configProblem{
+		file:    v.file,
+		line:    node.Line,
+		column:  node.Column,
+		message: fmt.Sprintf(format, args...),
+	})
+}
+
+// parseChapterConfig reads a self-contained chapter configuration in one of the
+// formats, or in the format detectFormat guesses when format is empty. A
+// configuration that decodes but is not a valid chapter configuration gives a
+// *configError with every problem in it, rather than only the first.
+func parseChapterConfig(data string, format string) (ChapterConfig, error) {
+	return readChapterConfig(data, format, &configValidator{})
+}
+
+// parseRoster reads a chapter configuration like parseChapterConfig, along with
+// the configurations it includes, which are looked up in includes by name.
+func parseRoster(data string, format string, includes map[string]string) (ChapterConfig, error) {
+	return readChapterConfig(data, format, &configValidator{includes: includes})
+}
+
+// parseOverlay reads a chapter configuration like parseChapterConfig, but allows
+// members to be marked with remove: true.
+func parseOverlay(data string, format string) (ChapterConfig, error) {
+	return readChapterConfig(data, format, &configValidator{overlay: true})
+}
+
+func readChapterConfig(data string, format string, v *configValidator) (ChapterConfig, error) {
+	var document *yaml.Node
+	var err error
+
+	if format == "" {
+		document, err = decodeDetected(data)
+	} else {
+		document, err = decodeDocument(data, format)
+	}
 
-	// Parse the data into the ChapterConfig struct
-	err := yaml.Unmarshal([]byte(data), &parsedConfig)
 	if err != nil {
 		return ChapterConfig{}, err
 	}
-	return parsedConfig, nil
+
+	// An empty input has no content, and is an empty configuration
+	if len(document.Content) == 0 {
+		return ChapterConfig{}, nil
+	}
+
+	root := document.Content[0]
+
+	if root.Kind != yaml.MappingNode {
+		v.add(root, "expected a mapping of chapters to their members")
+		return ChapterConfig{}, &configError{problems: v.problems}
+	}
+
+	var config ChapterConfig
+	if v.version = v.readVersion(root); v.version == 2 {
+		config = v.version2(root)
+	} else {
+		config = v.chapters(root, v.version1Chapter)
+	}
+
+	if len(v.problems) > 0 {
+		return ChapterConfig{}, &configError{problems: v.problems}
+	}
+
+	return config, nil
+}
+
+// readVersion returns the version of the configuration in root. A version 1
+// configuration can leave it out, and have a chapter named version.
+func (v *configValidator) readVersion(root *yaml.Node) int {
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+
+		if key.Value != "version" || value.Kind != yaml.ScalarNode || value.Tag == "!!null" {
+			continue
+		}
+
+		switch value.Value {
+		case "1":
+			return 1
+		case "2":
+			return 2
+		}
+
+		v.add(value, "unknown version %q, expected 1 or 2", value.Value)
+		return 1
+	}
+
+	return 1
+}
+
+// chapters reads a mapping of chapters, each read by chapter. The version
+// of a version 1 configuration is skipped.
+func (v *configValidator) chapters(node *yaml.Node, chapter func(name string, key *yaml.Node, value *yaml.Node) Chapter) ChapterConfig {
+	config := ChapterConfig{}
+
+	chapterLines := map[string]int{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		name := key.Value
+
+		if v.version == 1 && name == "version" && value.Kind == yaml.ScalarNode && value.Tag != "!!null" {
+			continue
+		}
+
+		if strings.TrimSpace(name) == "" {
+			v.add(key, "chapter with an empty name")
+		}
+
+		if line, found := chapterLines[name]; found {
+			v.add(key, "chapter %q is already listed on line %d", name, line)
+			continue
+		}
+		chapterLines[name] = key.Line
+
+		config[name] = chapter(name, key, value)
+	}
+
+	return config
+}
+
+// version1Chapter reads a chapter of a version 1 configuration, which is a list of
+// its members.
+func (v *configValidator) version1Chapter(name string, key *yaml.Node, value *yaml.Node) Chapter {
+	return Chapter{Members: v.members(name, key, value)}
+}
+
+// version2 reads a version 2 configuration, with its chapters and the
+// configurations it includes.
+func (v *configValidator) version2(root *yaml.Node) ChapterConfig {
+	config := ChapterConfig{}
+	var include *yaml.Node
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+
+		switch key.Value {
+		case "version":
+		case "chapters":
+			if value.Tag == "!!null" {
+				continue
+			}
+			if value.Kind != yaml.MappingNode {
+				v.add(value, "expected a mapping of chapters")
+				continue
+			}
+			config = v.chapters(value, v.version2Chapter)
+		case "include":
+			include = value
+		default:
+			v.add(key, "unknown field %q, a version 2 configuration has a version, chapters and include", key.Value)
+		}
+	}
+
+	if include != nil {
+		v.include(config, include)
+	}
+
+	return config
+}
+
+// version2Chapter reads a chapter of a version 2 configuration, which is a mapping
+// with its description, default role and members.
+func (v *configValidator) version2Chapter(name string, key *yaml.Node, value *yaml.Node) Chapter {
+	if value.Kind != yaml.MappingNode {
+		v.add(value, "expected chapter %q to have a description, default_role and members", name)
+		return Chapter{}
+	}
+
+	var chapter Chapter
+	var members *yaml.Node
+
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		field, content := value.Content[i], value.Content[i+1]
+
+		switch field.Value {
+		case "description", "default_role":
+			if content.Kind != yaml.ScalarNode {
+				v.add(content, "expected the %s of chapter %q to be a string", field.Value, name)
+				continue
+			}
+			if content.Tag == "!!null" {
+				continue
+			}
+
+			if field.Value == "description" {
+				chapter.Description = content.Value
+			} else if !slices.Contains(dataminded_api.Roles, content.Value) {
+				v.add(content, "default role %q of chapter %q is not one of %s", content.Value, name, strings.Join(dataminded_api.Roles, ", "))
+			} else {
+				chapter.DefaultRole = content.Value
+			}
+		case "members":
+			members = content
+		default:
+			v.add(field, "unknown field %q in chapter %q, a chapter has a description, default_role and members", field.Value, name)
+		}
+	}
+
+	if members == nil {
+		v.add(key, "chapter %q has no members", name)
+		return chapter
+	}
+
+	chapter.Members = v.members(name, key, members)
+
+	return chapter
+}
+
+// include adds the chapters of the configurations listed in node to config, and
+// reports the problems found in them as their own.
+func (v *configValidator) include(config ChapterConfig, node *yaml.Node) {
+	if node.Kind != yaml.SequenceNode {
+		v.add(node, "expected include to be a list of the names of configurations")
+		return
+	}
+
+	for _, item := range node.Content {
+		name := item.Value
+
+		data, found := v.includes[name]
+
+		switch {
+		case item.Kind != yaml.ScalarNode:
+			v.add(item, "expected the name of a configuration to include")
+			continue
+		case slices.Contains(v.including, name) || name == v.file:
+			v.add(item, "%q includes itself", name)
+			continue
+		case v.includes == nil:
+			v.add(item, "%q cannot be included, only chapter_config_roster takes the configurations to include", name)
+			continue
+		case !found:
+			v.add(item, "no configuration named %q is passed to include", name)
+			continue
+		}
+
+		included := &configValidator{
+			overlay:   v.overlay,
+			file:      name,
+			includes:  v.includes,
+			including: append(slices.Clone(v.including), v.file),
+		}
+
+		chapters, err := readChapterConfig(data, "", included)
+
+		var invalid *configError
+		switch {
+		case errors.As(err, &invalid):
+			v.problems = append(v.problems, invalid.problems...)
+			continue
+		case err != nil:
+			v.add(item, "including %q failed: %s", name, err)
+			continue
+		}
+
+		for _, chapter := range slices.Sorted(maps.Keys(chapters)) {
+			if _, found := config[chapter]; found {
+				v.add(item, "chapter %q of %q is already listed", chapter, name)
+				continue
+			}
+			config[chapter] = chapters[chapter]
+		}
+	}
+}
+
+// members reads the list of members of chapter, key is the node naming the chapter.
+func (v *configValidator) members(chapter string, key *yaml.Node, node *yaml.Node) []ChapterMember {
+	if node.Tag == "!!null" || (node.Kind == yaml.SequenceNode && len(node.Content) == 0) {
+		v.add(key, "chapter %q has no members", chapter)
+		return nil
+	}
+
+	if node.Kind != yaml.SequenceNode {
+		v.add(node, "expected a list of members of chapter %q", chapter)
+		return nil
+	}
+
+	var members []ChapterMember
+	var items []*yaml.Node
+	memberLines := map[string]int{}
+
+	for _, item := range node.Content {
+		member, ok := v.member(chapter, item)
+		if !ok {
+			continue
+		}
+
+		if line, found := memberLines[member.Name]; found {
+			// Nodes decoded from TOML have no line to refer to
+			if line == 0 {
+				v.add(item, "%q is listed twice in chapter %q", member.Name, chapter)
+			} else {
+				v.add(item, "%q is listed twice in chapter %q, first on line %d", member.Name, chapter, line)
+			}
+			continue
+		}
+		memberLines[member.Name] = item.Line
+
+		members = append(members, member)
+		items = append(items, item)
+	}
+
+	for _, clash := range aliasClashes(members) {
+		v.add(items[clash.index], "%s", clash.message(chapter))
+	}
+
+	return members
+}
+
+// aliasClash is an alias of the member at index that already names owner.
+type aliasClash struct {
+	index  int
+	member string
+	alias  string
+	owner  string
+}
+
+func (c aliasClash) message(chapter string) string {
+	return fmt.Sprintf("alias %q of %q in chapter %q already names %q", c.alias, c.member, chapter, c.owner)
+}
+
+// aliasClashes returns the aliases of members that name another member, or are an
+// earlier alias. An alias names its member as well, so it cannot name another.
+func aliasClashes(members []ChapterMember) []aliasClash {
+	owners := map[string]string{}
+	for _, member := range members {
+		owners[member.Name] = member.Name
+	}
+
+	var clashes []aliasClash
+	for i, member := range members {
+		for _, alias := range member.Aliases {
+			if owner, found := owners[alias]; found {
+				clashes = append(clashes, aliasClash{index: i, member: member.Name, alias: alias, owner: owner})
+				continue
+			}
+			owners[alias] = member.Name
+		}
+	}
+
+	return clashes
+}
+
+// member reads one member of chapter, ok is false when it has a problem.
+func (v *configValidator) member(chapter string, node *yaml.Node) (ChapterMember, bool) {
+	if node.Kind != yaml.MappingNode {
+		v.add(node, "expected a member of chapter %q with a name and an optional role", chapter)
+		return ChapterMember{}, false
+	}
+
+	problems := len(v.problems)
+	var member ChapterMember
+	var name, role *yaml.Node
+
+	fields := []string{"name", "role"}
+	if v.version == 2 {
+		fields = append(fields, "aliases")
+	}
+	if v.overlay {
+		fields = append(fields, "remove")
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+
+		if !slices.Contains(fields, key.Value) {
+			v.add(key, "unknown field %q in chapter %q, a member has a %s", key.Value, chapter, joinAnd(fields))
+			continue
+		}
+
+		if key.Value == "aliases" {
+			member.Aliases = v.aliases(chapter, value)
+			continue
+		}
+
+		if value.Kind != yaml.ScalarNode {
+			v.add(value, "expected the %s of a member of chapter %q to be a string", key.Value, chapter)
+			continue
+		}
+
+		switch key.Value {
+		case "name":
+			name = value
+			member.Name = value.Value
+		case "role":
+			role = value
+			// A role left empty, such as role: ~, is the default role
+			if value.Tag != "!!null" {
+				member.Role = value.Value
+			}
+		case "remove":
+			if err := value.Decode(&member.Remove); err != nil {
+				v.add(value, "expected remove of a member of chapter %q to be true or false", chapter)
+			}
+		}
+	}
+
+	if name == nil {
+		v.add(node, "member of chapter %q without a name", chapter)
+	} else if strings.TrimSpace(member.Name) == "" {
+		v.add(name, "member of chapter %q with an empty name", chapter)
+	}
+
+	if role != nil && role.Tag != "!!null" && !slices.Contains(dataminded_api.Roles, role.Value) {
+		v.add(role, "role %q of %q in chapter %q is not one of %s",
+			role.Value, member.Name, chapter, strings.Join(dataminded_api.Roles, ", "))
+	}
+
+	if member.Remove && role != nil {
+		v.add(role, "%q is removed from chapter %q, and cannot have a role as well", member.Name, chapter)
+	}
+
+	return member, len(v.problems) == problems
+}
+
+// aliases reads the aliases of a member of chapter.
+func (v *configValidator) aliases(chapter string, node *yaml.Node) []string {
+	if node.Kind != yaml.SequenceNode {
+		v.add(node, "expected the aliases of a member of chapter %q to be a list of names", chapter)
+		return nil
+	}
+
+	var aliases []string
+	for _, item := range node.Content {
+		if item.Kind != yaml.ScalarNode || strings.TrimSpace(item.Value) == "" || item.Tag == "!!null" {
+			v.add(item, "expected an alias of a member of chapter %q to be a name", chapter)
+			continue
+		}
+		aliases = append(aliases, item.Value)
+	}
+
+	return aliases
+}
+
+// joinAnd joins words as in "name, role and remove".
+func joinAnd(words []string) string {
+	if len(words) < 2 {
+		return strings.Join(words, "")
+	}
+
+	return strings.Join(words[:len(words)-1], ", ") + " and " + words[len(words)-1]
 }
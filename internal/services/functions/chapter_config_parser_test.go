@@ -0,0 +1,334 @@
+package functions_test
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"terraform-provider-dataminded/internal/acceptance"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+)
+
+type ConfigParser struct{}
+
+func membership(chapter string, name string, role string) knownvalue.Check {
+	return knownvalue.ObjectExact(map[string]knownvalue.Check{
+		"chapter": knownvalue.StringExact(chapter),
+		"name":    knownvalue.StringExact(name),
+		"role":    knownvalue.StringExact(role),
+	})
+}
+
+func TestAccChapterConfigParser(t *testing.T) {
+	f := ConfigParser{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: f.parse(`
+					analytics:
+					  - name: Michiel
+					    role: Lead
+					  - name: Wouter
+					platform:
+					  - name: Jonathan
+					    role: Contributor
+				`),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.MapExact(map[string]knownvalue.Check{
+						"analytics-Michiel": membership("analytics", "Michiel", "Lead"),
+						// A member without a role is a Contributor
+						"analytics-Wouter":  membership("analytics", "Wouter", "Contributor"),
+						"platform-Jonathan": membership("platform", "Jonathan", "Contributor"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccChapterConfigParserEmpty(t *testing.T) {
+	f := ConfigParser{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   f.parse(``),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.MapExact(map[string]knownvalue.Check{})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccChapterConfigParserInvalid(t *testing.T) {
+	f := ConfigParser{}
+
+	tests := []struct {
+		name    string
+		input   string
+		message string
+	}{
+		{
+			name:    "not yaml",
+			input:   `analytics: [`,
+			message: `Parsing the chapter configuration\s+failed`,
+		},
+		{
+			name:    "not a list of members",
+			input:   `analytics: Michiel`,
+			message: `line 1, column 12:\s+expected\s+a\s+list\s+of\s+members`,
+		},
+		{
+			name: "duplicate member",
+			input: `
+				analytics:
+				  - name: Michiel
+				  - name: Michiel
+			`,
+			message: `line 3, column 5:\s+"Michiel"\s+is\s+listed\s+twice\s+in\s+chapter\s+"analytics",\s+first\s+on\s+line\s+2`,
+		},
+		{
+			name: "invalid role",
+			input: `
+				analytics:
+				  - name: Michiel
+				    role: lead
+			`,
+			message: `line 3, column 11:\s+role\s+"lead"\s+of\s+"Michiel"\s+in\s+chapter\s+"analytics"\s+is\s+not\s+one\s+of\s+Lead,\s+Contributor`,
+		},
+		{
+			name:    "not a mapping",
+			input:   `- analytics`,
+			message: `line 1, column 1:\s+expected\s+a\s+mapping\s+of\s+chapters`,
+		},
+		{
+			name: "every problem",
+			input: `
+				analytics:
+				  - name: ""
+				  - role: Lead
+				  - name: Wouter
+				    rol: Lead
+				platform:
+				data: []
+			`,
+			message: `(?s)` +
+				`line 2, column 11:\s+member\s+of\s+chapter\s+"analytics"\s+with\s+an\s+empty\s+name.*` +
+				`line 3, column 5:\s+member\s+of\s+chapter\s+"analytics"\s+without\s+a\s+name.*` +
+				`line 5, column 5:\s+unknown\s+field\s+"rol".*` +
+				`line 6, column 1:\s+chapter\s+"platform"\s+has\s+no\s+members.*` +
+				`line 7, column 1:\s+chapter\s+"data"\s+has\s+no\s+members`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				Steps: []resource.TestStep{
+					{
+						Config:                   f.parse(test.input),
+						ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+						ExpectError:              regexp.MustCompile(test.message),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccChapterConfigParserVersion1(t *testing.T) {
+	f := ConfigParser{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				// A version 1 configuration can say so, or have a chapter named version
+				Config: f.parse(`
+					version: 1
+					analytics:
+					  - name: Michiel
+				`),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.MapExact(map[string]knownvalue.Check{
+						"analytics-Michiel": membership("analytics", "Michiel", "Contributor"),
+					})),
+				},
+			},
+			{
+				Config: f.parse(`
+					version:
+					  - name: Michiel
+				`),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.MapExact(map[string]knownvalue.Check{
+						"version-Michiel": membership("version", "Michiel", "Contributor"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccChapterConfigParserVersion2(t *testing.T) {
+	f := ConfigParser{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: f.parse(`
+					version: 2
+					chapters:
+					  analytics:
+					    description: Data analysis and reporting
+					    default_role: Lead
+					    members:
+					      - name: Michiel
+					        aliases: [mich, michiel.v]
+					      - name: Wouter
+					        role: Contributor
+					  platform:
+					    members:
+					      - name: Jonathan
+				`),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.MapExact(map[string]knownvalue.Check{
+						// A member without a role takes the default role of the chapter, and the
+						// memberships keep the shape of version 1
+						"analytics-Michiel": membership("analytics", "Michiel", "Lead"),
+						"analytics-Wouter":  membership("analytics", "Wouter", "Contributor"),
+						"platform-Jonathan": membership("platform", "Jonathan", "Contributor"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccChapterConfigParserVersion2Invalid(t *testing.T) {
+	f := ConfigParser{}
+
+	tests := []struct {
+		name    string
+		input   string
+		message string
+	}{
+		{
+			name:    "unknown version",
+			input:   `version: 3`,
+			message: `line 1, column 10:\s+unknown\s+version\s+"3",\s+expected\s+1\s+or\s+2`,
+		},
+		{
+			name: "aliases in version 1",
+			input: `
+				analytics:
+				  - name: Michiel
+				    aliases: [mich]
+			`,
+			message: `line 3, column 5:\s+unknown\s+field\s+"aliases"\s+in\s+chapter\s+"analytics",\s+a\s+member\s+has\s+a\s+name\s+and\s+role`,
+		},
+		{
+			name: "every problem",
+			input: `
+				version: 2
+				owners: []
+				chapters:
+				  analytics:
+				    default_role: lead
+				    lead: Michiel
+				    members:
+				      - name: Michiel
+				        aliases: [wouter]
+				      - name: Wouter
+				        aliases: mich
+				  platform:
+				    description: Without members
+				  data: []
+			`,
+			message: `(?s)` +
+				`line 2, column 1:\s+unknown\s+field\s+"owners",\s+a\s+version\s+2\s+configuration\s+has\s+a\s+version,\s+chapters\s+and\s+include.*` +
+				`line 5, column 19:\s+default\s+role\s+"lead"\s+of\s+chapter\s+"analytics"\s+is\s+not\s+one\s+of\s+Lead,\s+Contributor.*` +
+				`line 6, column 5:\s+unknown\s+field\s+"lead"\s+in\s+chapter\s+"analytics".*` +
+				`line 11, column 18:\s+expected\s+the\s+aliases\s+of\s+a\s+member\s+of\s+chapter\s+"analytics"\s+to\s+be\s+a\s+list.*` +
+				`line 12, column 3:\s+chapter\s+"platform"\s+has\s+no\s+members.*` +
+				`line 14, column 9:\s+expected\s+chapter\s+"data"\s+to\s+have\s+a\s+description,\s+default_role\s+and\s+members`,
+		},
+		{
+			name: "alias naming another member",
+			input: `
+				version: 2
+				chapters:
+				  analytics:
+				    members:
+				      - name: Michiel
+				        aliases: [Wouter]
+				      - name: Wouter
+			`,
+			message: `line 5, column 9:\s+alias\s+"Wouter"\s+of\s+"Michiel"\s+in\s+chapter\s+"analytics"\s+already\s+names\s+"Wouter"`,
+		},
+		{
+			name: "include",
+			input: `
+				version: 2
+				include: [teams.yaml]
+			`,
+			message: `line 2, column 11:\s+"teams.yaml"\s+cannot\s+be\s+included,\s+only\s+chapter_config_roster\s+takes\s+the\s+configurations\s+to\s+include`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				Steps: []resource.TestStep{
+					{
+						Config:                   f.parse(test.input),
+						ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+						ExpectError:              regexp.MustCompile(test.message),
+					},
+				},
+			})
+		})
+	}
+}
+
+// parse calls the function on input, dedented so that tests can indent their YAML.
+func (f ConfigParser) parse(input string) string {
+	return fmt.Sprintf(`
+		output "test" {
+			value = provider::dataminded::chapter_config_parser(%q)
+		}
+	`, dedent(input))
+}
+
+// dedent strips the tabs that all lines of s start with, and the surrounding
+// whitespace.
+func dedent(s string) string {
+	lines := strings.Split(strings.Trim(s, "\n"), "\n")
+
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		tabs := len(line) - len(strings.TrimLeft(line, "\t"))
+		if indent < 0 || tabs < indent {
+			indent = tabs
+		}
+	}
+
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, strings.Repeat("\t", max(indent, 0)))
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n")) + "\n"
+}
@@ -0,0 +1,259 @@
+package functions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"terraform-provider-dataminded/internal/dataminded_api"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ function.Function = ConfigRender{}
+)
+
+func NewConfigRender() function.Function {
+	return ConfigRender{}
+}
+
+type ConfigRender struct{}
+
+func (r ConfigRender) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "chapter_config_render"
+}
+
+func (r ConfigRender) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Render chapter configuration",
+		MarkdownDescription: "Render memberships as canonical chapter configuration YAML, the inverse of `chapter_config_parser` and " +
+			"`chapter_config_roster`: rendering what either returns and parsing that again gives the same memberships. The chapters and their members are " +
+			"sorted by name, so the same memberships always render alike. The configuration is version 2 when a chapter has " +
+			"a description or a member has aliases, and version 1 otherwise.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name: "memberships",
+				MarkdownDescription: "A map of memberships in the shape `chapter_config_parser` returns, objects with a `chapter`, " +
+					"`name` and `role`, or in the shape `chapter_config_roster` returns, with `aliases` and a `chapter_description` as well. The keys are not rendered, and a null " +
+					"role is a `Contributor`.",
+			},
+		},
+		VariadicParameter: function.BoolParameter{
+			Name:                "omit_default_roles",
+			MarkdownDescription: "Whether to leave out the role of members that are a `Contributor`, defaults to false.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (r ConfigRender) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var memberships types.Dynamic
+	var omitDefaultRoles []bool
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &memberships, &omitDefaultRoles))
+
+	if resp.Error != nil {
+		return
+	}
+
+	if len(omitDefaultRoles) > 1 {
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("Expected omit_default_roles at most once, got it %d times", len(omitDefaultRoles)))
+		return
+	}
+
+	config, err := renderedConfig(memberships.UnderlyingValue())
+
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid memberships:\n%s", err))
+		return
+	}
+
+	rendered, err := config.canonicalYAML(len(omitDefaultRoles) == 1 && omitDefaultRoles[0])
+
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Rendering the chapter configuration failed: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, rendered))
+}
+
+// renderedMembership is a membership to render, any of its attributes can be null.
+type renderedMembership struct {
+	Chapter            types.String
+	Name               types.String
+	Role               types.String
+	Aliases            []string
+	ChapterDescription types.String
+}
+
+// renderedConfig groups the memberships in a map or object by chapter. It reports
+// every membership that the parser would not accept, so that the result always
+// parses.
+func renderedConfig(value attr.Value) (ChapterConfig, error) {
+	var elements map[string]attr.Value
+
+	switch value := value.(type) {
+	case types.Map:
+		elements = value.Elements()
+	case types.Object:
+		elements = value.Attributes()
+	default:
+		return nil, fmt.Errorf("expected a map of memberships, got %s", value)
+	}
+
+	config := ChapterConfig{}
+	var problems []configProblem
+
+	problem := func(format string, args ...any) {
+		problems = append(problems, configProblem{message: fmt.Sprintf(format, args...)})
+	}
+
+	// Keys of the first membership of each member, by chapter and then name
+	keys := map[string]map[string]string{}
+	// Key of the first membership of each chapter, which gives its description
+	firsts := map[string]string{}
+
+	for _, key := range slices.Sorted(maps.Keys(elements)) {
+		m, err := readMembership(elements[key])
+		if err != nil {
+			problem("membership %q %s", key, err)
+			continue
+		}
+
+		chapter, name := m.Chapter.ValueString(), m.Name.ValueString()
+
+		switch {
+		case strings.TrimSpace(chapter) == "":
+			problem("membership %q has no chapter", key)
+			continue
+		case strings.TrimSpace(name) == "":
+			problem("membership %q has no name", key)
+			continue
+		case !m.Role.IsNull() && !slices.Contains(dataminded_api.Roles, m.Role.ValueString()):
+			problem("role %q of membership %q is not one of %s", m.Role.ValueString(), key, strings.Join(dataminded_api.Roles, ", "))
+			continue
+		}
+
+		if keys[chapter] == nil {
+			keys[chapter] = map[string]string{}
+		}
+
+		if other, found := keys[chapter][name]; found {
+			problem("memberships %q and %q are both %q in chapter %q", other, key, name, chapter)
+			continue
+		}
+		keys[chapter][name] = key
+
+		description := m.ChapterDescription.ValueString()
+		if first, found := firsts[chapter]; !found {
+			firsts[chapter] = key
+		} else if description != config[chapter].Description {
+			problem("memberships %q and %q give chapter %q another description", first, key, chapter)
+			continue
+		}
+
+		config[chapter] = Chapter{
+			Description: description,
+			Members:     append(config[chapter].Members, ChapterMember{Name: name, Role: m.Role.ValueString(), Aliases: m.Aliases}),
+		}
+	}
+
+	for _, chapter := range slices.Sorted(maps.Keys(config)) {
+		for _, clash := range aliasClashes(config[chapter].Members) {
+			problem("%s", clash.message(chapter))
+		}
+	}
+
+	// The parser would reject the rendered configuration
+	if _, err := config.memberships(); err != nil {
+		problem("%s", err)
+	}
+
+	if len(problems) > 0 {
+		return nil, &configError{problems: problems}
+	}
+
+	return config, nil
+}
+
+// readMembership reads a membership from an object, which need not have the
+// aliases and chapter_description attributes.
+func readMembership(value attr.Value) (renderedMembership, error) {
+	object, ok := value.(types.Object)
+	if !ok || object.IsNull() {
+		return renderedMembership{}, errors.New("is not an object")
+	}
+
+	attributes := object.Attributes()
+	var m renderedMembership
+
+	fields := []struct {
+		name   string
+		target *types.String
+	}{
+		{"chapter", &m.Chapter},
+		{"name", &m.Name},
+		{"role", &m.Role},
+		{"chapter_description", &m.ChapterDescription},
+	}
+
+	for _, field := range fields {
+		text, ok := stringValue(attributes[field.name])
+		if !ok {
+			return renderedMembership{}, fmt.Errorf("has a %s that is not a string", field.name)
+		}
+		*field.target = text
+	}
+
+	var aliases []attr.Value
+	switch value := attributes["aliases"].(type) {
+	case nil:
+	case types.List:
+		aliases = value.Elements()
+	case types.Tuple:
+		aliases = value.Elements()
+	case types.Set:
+		aliases = value.Elements()
+	case types.Dynamic:
+		if !value.IsNull() {
+			return renderedMembership{}, errors.New("has aliases that are not a list of names")
+		}
+	default:
+		return renderedMembership{}, errors.New("has aliases that are not a list of names")
+	}
+
+	for _, alias := range aliases {
+		text, ok := stringValue(alias)
+		if !ok || strings.TrimSpace(text.ValueString()) == "" {
+			return renderedMembership{}, errors.New("has aliases that are not a list of names")
+		}
+		m.Aliases = append(m.Aliases, text.ValueString())
+	}
+
+	return m, nil
+}
+
+// stringValue returns value as a string, which is null when value is missing or
+// null. Null attributes of an object written in Terraform are dynamic.
+func stringValue(value attr.Value) (types.String, bool) {
+	switch value := value.(type) {
+	case nil:
+		return types.StringNull(), true
+	case types.String:
+		return value, true
+	case types.Dynamic:
+		if value.IsNull() {
+			return types.StringNull(), true
+		}
+		return stringValue(value.UnderlyingValue())
+	}
+
+	return types.String{}, false
+}
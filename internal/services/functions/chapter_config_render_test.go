@@ -0,0 +1,363 @@
+package functions_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"testing"
+
+	"terraform-provider-dataminded/internal/acceptance"
+	"terraform-provider-dataminded/internal/services/functions"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"gopkg.in/yaml.v3"
+)
+
+type ConfigRender struct{}
+
+func TestAccChapterConfigRender(t *testing.T) {
+	f := ConfigRender{}
+
+	input := `
+		platform:
+		  - name: Wouter
+		  - name: Jonathan
+		    role: Lead
+		analytics:
+		  - {name: "123", role: Contributor}
+	`
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   f.render(input, ``),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ConfigStateChecks: []statecheck.StateCheck{
+					// Names that would read as another type are quoted
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact(dedent(`
+						analytics:
+						  - name: "123"
+						    role: Contributor
+						platform:
+						  - name: Jonathan
+						    role: Lead
+						  - name: Wouter
+						    role: Contributor
+					`))),
+				},
+			},
+			{
+				Config:                   f.render(input, `, true`),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact(dedent(`
+						analytics:
+						  - name: "123"
+						platform:
+						  - name: Jonathan
+						    role: Lead
+						  - name: Wouter
+					`))),
+				},
+			},
+		},
+	})
+}
+
+func TestAccChapterConfigRenderFromObjects(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				// Memberships built in Terraform rather than parsed, with any keys and a null role
+				Config: `
+					output "test" {
+						value = provider::dataminded::chapter_config_render({
+							first  = { chapter = "platform", name = "Wouter", role = null }
+							second = { chapter = "platform", name = "Jonathan", role = "Lead" }
+						})
+					}
+				`,
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact(dedent(`
+						platform:
+						  - name: Jonathan
+						    role: Lead
+						  - name: Wouter
+						    role: Contributor
+					`))),
+				},
+			},
+		},
+	})
+}
+
+func TestAccChapterConfigRenderVersion2(t *testing.T) {
+	f := ConfigRender{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				// The default role is spelled out, and only the chapters with a description have one
+				Config: f.render_roster(`
+					version: 2
+					chapters:
+					  analytics:
+					    description: Data analysis and reporting
+					    default_role: Lead
+					    members:
+					      - name: Michiel
+					        aliases: [mich]
+					  platform:
+					    members:
+					      - name: Jonathan
+				`, `, true`),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact(dedent(`
+						version: 2
+						chapters:
+						  analytics:
+						    description: Data analysis and reporting
+						    members:
+						      - name: Michiel
+						        role: Lead
+						        aliases: [mich]
+						  platform:
+						    members:
+						      - name: Jonathan
+					`))),
+				},
+			},
+		},
+	})
+}
+
+func TestAccChapterConfigRenderInvalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					output "test" {
+						value = provider::dataminded::chapter_config_render({
+							a = { chapter = "", name = "Wouter", role = null }
+							b = { chapter = "platform", name = null, role = null }
+							c = { chapter = "platform", name = "Jonathan", role = "lead" }
+							d = { chapter = "platform", name = "Michiel", role = null }
+							e = { chapter = "platform", name = "Michiel", role = "Lead" }
+							f = { chapter = "analytics", name = "Michiel", role = null, chapter_description = "Reporting" }
+							g = { chapter = "analytics", name = "Wouter", role = null, chapter_description = "Analysis" }
+							h = { chapter = "security", name = "Bob", role = null, aliases = ["Al"] }
+							i = { chapter = "security", name = "Al", role = null, aliases = [] }
+							j = { chapter = "governance", name = "Bob", role = null, aliases = ["Al", "Al"] }
+							k = { chapter = "a-b", name = "c", role = null }
+							l = { chapter = "a", name = "b-c", role = null }
+						})
+					}
+				`,
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ExpectError: regexp.MustCompile(`(?s)Invalid\s+memberships.*` +
+					`membership\s+"a"\s+has\s+no\s+chapter.*` +
+					`membership\s+"b"\s+has\s+no\s+name.*` +
+					`role\s+"lead"\s+of\s+membership\s+"c"\s+is\s+not\s+one\s+of\s+Lead,\s+Contributor.*` +
+					`memberships\s+"d"\s+and\s+"e"\s+are\s+both\s+"Michiel"\s+in\s+chapter\s+"platform".*` +
+					`memberships\s+"f"\s+and\s+"g"\s+give\s+chapter\s+"analytics"\s+another\s+description.*` +
+					`alias\s+"Al"\s+of\s+"Bob"\s+in\s+chapter\s+"governance"\s+already\s+names\s+"Bob".*` +
+					`alias\s+"Al"\s+of\s+"Bob"\s+in\s+chapter\s+"security"\s+already\s+names\s+"Al".*` +
+					`"c"\s+in\s+chapter\s+"a-b"\s+and\s+"b-c"\s+in\s+chapter\s+"a"\s+share\s+the\s+key\s+"a-b-c"`),
+			},
+		},
+	})
+}
+
+// TestChapterConfigRenderRoundTrip checks, on random chapter configurations, that
+// rendering what the parser or the roster returns gives a configuration that parses
+// to the same memberships, and renders the same again.
+func TestChapterConfigRenderRoundTrip(t *testing.T) {
+	random := rand.New(rand.NewPCG(1, 2))
+
+	parsers := map[string]func(input string) (attr.Value, *function.FuncError){
+		"parser": func(input string) (attr.Value, *function.FuncError) {
+			return call(t, functions.NewConfigParser(), types.StringValue(input), emptyTuple())
+		},
+		"roster": func(input string) (attr.Value, *function.FuncError) {
+			return call(t, functions.NewConfigRoster(), types.StringValue(input), types.MapValueMust(types.StringType, map[string]attr.Value{}), emptyTuple())
+		},
+	}
+
+	for i := range 500 {
+		input := randomChapterConfig(t, random)
+		omitDefaultRoles := random.IntN(2) == 0
+
+		for name, parse := range parsers {
+			parsed, err := parse(input)
+			if err != nil {
+				t.Fatalf("case %d: %s failed: %s\n%s", i, name, err, input)
+			}
+
+			rendered := render(t, parsed, omitDefaultRoles)
+
+			reparsed, err := parse(rendered)
+			if err != nil {
+				t.Fatalf("case %d: %s failed on the rendered configuration: %s\n%s", i, name, err, rendered)
+			}
+
+			if !parsed.Equal(reparsed) {
+				t.Fatalf("case %d: the rendered configuration gives the %s other memberships\ninput:\n%s\nrendered:\n%s", i, name, input, rendered)
+			}
+
+			if again := render(t, reparsed, omitDefaultRoles); again != rendered {
+				t.Fatalf("case %d: rendering what the %s returns is not stable\nfirst:\n%s\nsecond:\n%s", i, name, rendered, again)
+			}
+		}
+	}
+}
+
+// render calls chapter_config_render on memberships returned by the parser or roster.
+func render(t *testing.T, memberships attr.Value, omitDefaultRoles bool) string {
+	result, err := call(t, functions.NewConfigRender(), types.DynamicValue(memberships), types.TupleValueMust(
+		[]attr.Type{types.BoolType}, []attr.Value{types.BoolValue(omitDefaultRoles)},
+	))
+	if err != nil {
+		t.Fatalf("rendering failed: %s", err)
+	}
+
+	return result.(types.String).ValueString()
+}
+
+// call runs fn without Terraform, the arguments of a variadic parameter go in a
+// tuple after the others.
+func call(t *testing.T, fn function.Function, arguments ...attr.Value) (attr.Value, *function.FuncError) {
+	ctx := context.Background()
+
+	var definition function.DefinitionResponse
+	fn.Definition(ctx, function.DefinitionRequest{}, &definition)
+
+	returnType := definition.Definition.Return.GetType()
+	unknown, err := returnType.ValueFromTerraform(ctx, tftypes.NewValue(returnType.TerraformType(ctx), tftypes.UnknownValue))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := function.RunResponse{Result: function.NewResultData(unknown)}
+	fn.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(arguments)}, &resp)
+
+	return resp.Result.Value(), resp.Error
+}
+
+func emptyTuple() attr.Value {
+	return types.TupleValueMust([]attr.Type{}, []attr.Value{})
+}
+
+// Names that YAML would read as another type or has to quote.
+var (
+	randomChapters = []string{"analytics", "platform", "data", "data-eng", "yes", "123", "null", "a b", "Ünïcode", "#hash", "x: y", "- list"}
+	randomNames    = []string{"Wouter", "Michiel", "Jonathan", "no", "1e3", "~", "- dash", " spaced ", "'quoted'", "a: b", "Ünïcode"}
+	randomRoles    = []string{"", "~", "Lead", "Contributor"}
+)
+
+// randomChapterConfig returns a valid chapter configuration with chapters and
+// members in random order, and roles that are left out, null, or spelled out. Half
+// of them are version 2, with descriptions, default roles and aliases.
+func randomChapterConfig(t *testing.T, random *rand.Rand) string {
+	version2 := random.IntN(2) == 0
+	chapters := &yaml.Node{Kind: yaml.MappingNode}
+
+	for _, chapter := range pick(random, randomChapters, len(randomChapters)) {
+		list := &yaml.Node{Kind: yaml.SequenceNode}
+		if random.IntN(4) == 0 {
+			list.Style = yaml.FlowStyle
+		}
+
+		for _, name := range pick(random, randomNames, 3) {
+			member := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{randomScalar("name"), randomScalar(name)}}
+
+			switch role := randomRoles[random.IntN(len(randomRoles))]; role {
+			case "":
+			case "~":
+				member.Content = append(member.Content, randomScalar("role"), &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "~"})
+			default:
+				member.Content = append(member.Content, randomScalar("role"), randomScalar(role))
+			}
+
+			// Names in a chapter differ, and so do their aliases
+			if version2 && random.IntN(2) == 0 {
+				aliases := &yaml.Node{Kind: yaml.SequenceNode}
+				for i := range random.IntN(3) {
+					aliases.Content = append(aliases.Content, randomScalar(fmt.Sprintf("aka %d %s", i, name)))
+				}
+				member.Content = append(member.Content, randomScalar("aliases"), aliases)
+			}
+
+			list.Content = append(list.Content, member)
+		}
+
+		if !version2 {
+			chapters.Content = append(chapters.Content, randomScalar(chapter), list)
+			continue
+		}
+
+		fields := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{randomScalar("members"), list}}
+		if random.IntN(2) == 0 {
+			fields.Content = append(fields.Content, randomScalar("description"), randomScalar(randomNames[random.IntN(len(randomNames))]))
+		}
+		if role := randomRoles[random.IntN(len(randomRoles))]; role != "" && role != "~" {
+			fields.Content = append(fields.Content, randomScalar("default_role"), randomScalar(role))
+		}
+
+		chapters.Content = append(chapters.Content, randomScalar(chapter), fields)
+	}
+
+	root := chapters
+	if version2 {
+		root = &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+			randomScalar("chapters"), chapters,
+			randomScalar("version"), {Kind: yaml.ScalarNode, Tag: "!!int", Value: "2"},
+		}}
+	}
+
+	out, err := yaml.Marshal(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(out)
+}
+
+// pick returns a random selection of at least one and at most most of values, in
+// random order.
+func pick(random *rand.Rand, values []string, most int) []string {
+	shuffled := append([]string{}, values...)
+	random.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	return shuffled[:1+random.IntN(most)]
+}
+
+func randomScalar(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+func (f ConfigRender) render(input string, arguments string) string {
+	return fmt.Sprintf(`
+		output "test" {
+			value = provider::dataminded::chapter_config_render(provider::dataminded::chapter_config_parser(%q)%s)
+		}
+	`, dedent(input), arguments)
+}
+
+func (f ConfigRender) render_roster(input string, arguments string) string {
+	return fmt.Sprintf(`
+		output "test" {
+			value = provider::dataminded::chapter_config_render(provider::dataminded::chapter_config_roster(%q, {})%s)
+		}
+	`, dedent(input), arguments)
+}
@@ -0,0 +1,143 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ function.Function = ConfigRoster{}
+)
+
+func NewConfigRoster() function.Function {
+	return ConfigRoster{}
+}
+
+type ConfigRoster struct{}
+
+func (r ConfigRoster) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "chapter_config_roster"
+}
+
+func (r ConfigRoster) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a chapter roster",
+		MarkdownDescription: "Parse chapter configuration like `chapter_config_parser`, along with the configurations it includes, " +
+			"and return its memberships with the `aliases` of their member and the `chapter_description`, which are empty when " +
+			"there are none.\n\n" +
+			"A configuration starting with `version: 2` has its chapters under `chapters`, each with an optional `description` " +
+			"and `default_role` and its `members`, which may have `aliases`. It can `include` a list of other configurations " +
+			"by the name they are passed under, which may be of either version but cannot list the same chapter.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name: "input",
+				MarkdownDescription: "The chapter configuration, of version 1 or 2. Written in YAML, JSON, or TOML with a table " +
+					"array per chapter.",
+			},
+			function.MapParameter{
+				Name: "includes",
+				MarkdownDescription: "The configurations that can be included, by name, such as `{ \"teams.yaml\" = file(\"teams.yaml\") }`. " +
+					"Their format is always detected.",
+				ElementType: types.StringType,
+			},
+		},
+		VariadicParameter: formatParameter,
+		Return: function.MapReturn{
+			ElementType: types.ObjectType{
+				AttrTypes: rosterMembershipAttributeTypes,
+			},
+		},
+	}
+}
+
+func (r ConfigRoster) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var data string
+	var includes map[string]string
+
+	resp.Error = function.ConcatFuncErrors(
+		req.Arguments.GetArgument(ctx, 0, &data),
+		req.Arguments.GetArgument(ctx, 1, &includes),
+	)
+
+	if resp.Error != nil {
+		return
+	}
+
+	format, funcErr := formatArgument(ctx, req, 2)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	// Not nil, so that the input can include configurations
+	if includes == nil {
+		includes = map[string]string{}
+	}
+
+	config, err := parseRoster(data, format, includes)
+
+	if err != nil {
+		resp.Error = configFuncError(0, err)
+		return
+	}
+
+	memberships, err := config.rosterMemberships()
+
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid chapter configuration: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, memberships))
+}
+
+// rosterMembershipAttributeTypes are the attributes of a membership returned by
+// the roster.
+var rosterMembershipAttributeTypes = map[string]attr.Type{
+	"chapter":             types.StringType,
+	"name":                types.StringType,
+	"role":                types.StringType,
+	"aliases":             types.ListType{ElemType: types.StringType},
+	"chapter_description": types.StringType,
+}
+
+// rosterMembership is a membership as returned by the roster.
+type rosterMembership struct {
+	Chapter            string   `tfsdk:"chapter"`
+	Name               string   `tfsdk:"name"`
+	Role               string   `tfsdk:"role"`
+	Aliases            []string `tfsdk:"aliases"`
+	ChapterDescription string   `tfsdk:"chapter_description"`
+}
+
+// rosterMemberships flattens the config into its memberships like memberships,
+// along with their aliases and chapter description.
+func (c ChapterConfig) rosterMemberships() (map[string]rosterMembership, error) {
+	memberships, err := c.memberships()
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]rosterMembership{}
+
+	for name, chapter := range c {
+		for _, member := range chapter.Members {
+			m := memberships[membershipKey(name, member.Name)]
+
+			result[membershipKey(name, member.Name)] = rosterMembership{
+				Chapter: m.Chapter,
+				Name:    m.Name,
+				Role:    m.Role,
+				// An empty list rather than null, as for version 1 configurations
+				Aliases:            append([]string{}, member.Aliases...),
+				ChapterDescription: chapter.Description,
+			}
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,231 @@
+package functions_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"terraform-provider-dataminded/internal/acceptance"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+)
+
+type ConfigRoster struct{}
+
+// rosterMembership checks a membership returned by the roster.
+func rosterMembership(chapter string, description string, name string, role string, aliases ...string) knownvalue.Check {
+	checks := []knownvalue.Check{}
+	for _, alias := range aliases {
+		checks = append(checks, knownvalue.StringExact(alias))
+	}
+
+	return knownvalue.ObjectExact(map[string]knownvalue.Check{
+		"chapter":             knownvalue.StringExact(chapter),
+		"name":                knownvalue.StringExact(name),
+		"role":                knownvalue.StringExact(role),
+		"aliases":             knownvalue.ListExact(checks),
+		"chapter_description": knownvalue.StringExact(description),
+	})
+}
+
+func TestAccChapterConfigRoster(t *testing.T) {
+	f := ConfigRoster{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: f.roster(`
+					version: 2
+					chapters:
+					  analytics:
+					    description: Data analysis and reporting
+					    default_role: Lead
+					    members:
+					      - name: Michiel
+					        aliases: [mich, michiel.v]
+					      - name: Wouter
+					        role: Contributor
+					  platform:
+					    members:
+					      - name: Jonathan
+				`, `{}`),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.MapExact(map[string]knownvalue.Check{
+						"analytics-Michiel": rosterMembership("analytics", "Data analysis and reporting", "Michiel", "Lead", "mich", "michiel.v"),
+						"analytics-Wouter":  rosterMembership("analytics", "Data analysis and reporting", "Wouter", "Contributor"),
+						"platform-Jonathan": rosterMembership("platform", "", "Jonathan", "Contributor"),
+					})),
+				},
+			},
+			{
+				// A version 1 configuration has neither aliases nor descriptions
+				Config: f.roster(`
+					analytics:
+					  - name: Michiel
+				`, `{}`),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.MapExact(map[string]knownvalue.Check{
+						"analytics-Michiel": rosterMembership("analytics", "", "Michiel", "Contributor"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccChapterConfigRosterIncludes(t *testing.T) {
+	f := ConfigRoster{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				// Included configurations can be of either version and format, and include others
+				Config: f.roster(`
+					version: 2
+					include: [analytics.yaml, platform.json]
+				`, fmt.Sprintf(`{
+					"analytics.yaml" = %q
+					"platform.json"  = %q
+					"data.toml"      = %q
+				}`, dedent(`
+					analytics:
+					  - name: Michiel
+					    role: Lead
+				`), `{"version": 2, "include": ["data.toml"], "chapters": {"platform": {"default_role": "Lead", "members": [{"name": "Jonathan"}]}}}`, dedent(`
+					[[data]]
+					name = "Kris"
+				`)), `"yaml"`),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.MapExact(map[string]knownvalue.Check{
+						"analytics-Michiel": rosterMembership("analytics", "", "Michiel", "Lead"),
+						"platform-Jonathan": rosterMembership("platform", "", "Jonathan", "Lead"),
+						"data-Kris":         rosterMembership("data", "", "Kris", "Contributor"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccChapterConfigRosterInvalid(t *testing.T) {
+	f := ConfigRoster{}
+
+	tests := []struct {
+		name      string
+		includes  map[string]string
+		arguments string
+		message   string
+	}{
+		{
+			name:    "include not passed",
+			message: `line 2, column 11:\s+no\s+configuration\s+named\s+"teams.yaml"\s+is\s+passed\s+to\s+include`,
+		},
+		{
+			name: "problem in an included configuration",
+			includes: map[string]string{
+				"teams.yaml": "analytics:\n  - name: Michiel\n    role: lead\n",
+			},
+			message: `teams.yaml:\s+line\s+3,\s+column\s+11:\s+role\s+"lead"`,
+		},
+		{
+			name: "chapter listed twice",
+			includes: map[string]string{
+				"teams.yaml": "platform:\n  - name: Michiel\n",
+			},
+			message: `line 2, column 11:\s+chapter\s+"platform"\s+of\s+"teams.yaml"\s+is\s+already\s+listed`,
+		},
+		{
+			name: "include cycle",
+			includes: map[string]string{
+				"teams.yaml": "version: 2\ninclude: [more.yaml]\n",
+				"more.yaml":  "version: 2\ninclude: [teams.yaml]\n",
+			},
+			message: `more.yaml:\s+line\s+2,\s+column\s+11:\s+"teams.yaml"\s+includes\s+itself`,
+		},
+		{
+			name:      "unknown format",
+			arguments: `"xml"`,
+			message:   `Invalid\s+value\s+for\s+"format"\s+parameter:\s+Unknown\s+format\s+"xml"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			includes := "{\n"
+			for name, content := range test.includes {
+				includes += fmt.Sprintf("%q = %q\n", name, content)
+			}
+			includes += "}"
+
+			arguments := []string{includes}
+			if test.arguments != "" {
+				arguments = append(arguments, test.arguments)
+			}
+
+			resource.Test(t, resource.TestCase{
+				Steps: []resource.TestStep{
+					{
+						Config: f.roster(`
+							version: 2
+							include: [teams.yaml]
+							chapters:
+							  platform:
+							    members:
+							      - name: Jonathan
+						`, arguments...),
+						ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+						ExpectError:              regexp.MustCompile(test.message),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccChapterConfigRosterIncludesIntoDiff(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				// Rendering the roster makes it self-contained, for diff and merge
+				Config: fmt.Sprintf(`
+					locals {
+						includes = { "teams.yaml" = %q }
+					}
+
+					output "test" {
+						value = provider::dataminded::chapter_config_diff(
+							provider::dataminded::chapter_config_render(provider::dataminded::chapter_config_roster(%q, local.includes)),
+							provider::dataminded::chapter_config_render(provider::dataminded::chapter_config_roster(%q, local.includes)),
+						).added_members
+					}
+				`, "analytics:\n  - name: Michiel\n", "version: 2\ninclude: [teams.yaml]\n", "version: 2\ninclude: [teams.yaml]\nchapters:\n  platform:\n    members:\n      - name: Jonathan\n"),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.ListExact([]knownvalue.Check{
+						membership("platform", "Jonathan", "Contributor"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+// roster calls the function on input, dedented so that tests can indent their YAML,
+// followed by the HCL arguments.
+func (f ConfigRoster) roster(input string, arguments ...string) string {
+	call := fmt.Sprintf("%q", dedent(input))
+	for _, argument := range arguments {
+		call += ", " + argument
+	}
+
+	return fmt.Sprintf(`
+		output "test" {
+			value = provider::dataminded::chapter_config_roster(%s)
+		}
+	`, call)
+}
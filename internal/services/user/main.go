@@ -5,11 +5,34 @@ import (
 )
 
 type UserDatasourceModel struct {
+	Id       types.Int64        `tfsdk:"id"`
+	Name     types.String       `tfsdk:"name"`
+	Chapters []UserChapterModel `tfsdk:"chapters"`
+}
+
+// UserChapterModel is a chapter the user is a member of, with the user's role in it.
+type UserChapterModel struct {
 	Id   types.Int64  `tfsdk:"id"`
 	Name types.String `tfsdk:"name"`
+	Role types.String `tfsdk:"role"`
 }
 
 type UserResourceModel struct {
 	Id   types.Int64  `tfsdk:"id"`
 	Name types.String `tfsdk:"name"`
 }
+
+type UsersDataSourceModel struct {
+	NameRegex       types.String                `tfsdk:"name_regex"`
+	NamePrefix      types.String                `tfsdk:"name_prefix"`
+	Ids             types.Set                   `tfsdk:"ids"`
+	MemberOfChapter types.Int64                 `tfsdk:"member_of_chapter"`
+	Users           []UserSummaryModel          `tfsdk:"users"`
+	ByName          map[string]UserSummaryModel `tfsdk:"by_name"`
+}
+
+// UserSummaryModel is a user as listed by the users data source.
+type UserSummaryModel struct {
+	Id   types.Int64  `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
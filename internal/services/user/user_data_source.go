@@ -0,0 +1,192 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"terraform-provider-dataminded/internal/dataminded_api"
+	"terraform-provider-dataminded/internal/logging"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &UserDataSource{}
+	_ datasource.DataSourceWithConfigure        = &UserDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &UserDataSource{}
+)
+
+func NewUserDataSource() datasource.DataSource {
+	return &UserDataSource{}
+}
+
+type UserDataSource struct {
+	Client *dataminded_api.Client
+}
+
+func (d *UserDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+func (d *UserDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Look up a Dataminded user by id or by name",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Id of the user in the sqlite database. Exactly one of id and name must be set.",
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Exact name of the user. Exactly one of id and name must be set, a name shared by several users is an error.",
+			},
+			"chapters": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Chapters the user is a member of, sorted by name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Id of the chapter",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the chapter",
+						},
+						"role": schema.StringAttribute{
+							Computed:    true,
+							Description: "Role of the user in the chapter, Lead or Contributor",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *UserDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("name")),
+	}
+}
+
+func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = logging.WithDiagnostics(ctx, &resp.Diagnostics)
+
+	var config UserDatasourceModel
+
+	// Read input configured in data block
+	resp.Diagnostics.Append(
+		req.Config.Get(ctx, &config)...,
+	)
+
+	if logging.HasError(ctx) {
+		return
+	}
+
+	id := int(config.Id.ValueInt64())
+
+	if !config.Name.IsNull() {
+		var err error
+		id, err = d.Client.UserIdByName(ctx, config.Name.ValueString())
+
+		if err != nil {
+			logging.AddError(ctx, "Looking up user by name failed", err)
+			return
+		}
+	}
+
+	user, err := d.Client.ReadUser(ctx, id)
+
+	if err != nil {
+		logging.AddError(ctx, "Reading user failed", err)
+		return
+	}
+
+	chapters, err := d.chapters(ctx, user.Id)
+
+	if err != nil {
+		logging.AddError(ctx, "Reading chapters of user failed", err)
+		return
+	}
+
+	state := UserDatasourceModel{
+		Id:       types.Int64Value(int64(user.Id)),
+		Name:     types.StringValue(user.Name),
+		Chapters: chapters,
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// chapters returns the chapters the user is a member of, sorted by name and then id.
+func (d *UserDataSource) chapters(ctx context.Context, userId int) ([]UserChapterModel, error) {
+	memberships, err := d.Client.ListMembershipsForUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := map[int]string{}
+	for _, membership := range memberships {
+		roles[membership.ChapterId] = membership.Role
+	}
+
+	// The chapters are listed regardless, an empty list is more useful than null
+	result := []UserChapterModel{}
+	if len(roles) == 0 {
+		return result, nil
+	}
+
+	chapters, err := d.Client.ListChapters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, chapter := range chapters {
+		if role, ok := roles[chapter.Id]; ok {
+			result = append(result, UserChapterModel{
+				Id:   types.Int64Value(int64(chapter.Id)),
+				Name: types.StringValue(chapter.Name),
+				Role: types.StringValue(role),
+			})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Name.ValueString() != result[j].Name.ValueString() {
+			return result[i].Name.ValueString() < result[j].Name.ValueString()
+		}
+		return result[i].Id.ValueInt64() < result[j].Id.ValueInt64()
+	})
+
+	return result, nil
+}
+
+func (d *UserDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*dataminded_api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Configure Type",
+			fmt.Sprintf("Expected *dataminded_api.Client got: %T.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.Client = client
+}
@@ -0,0 +1,228 @@
+package user_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"terraform-provider-dataminded/internal/acceptance"
+	"terraform-provider-dataminded/internal/dataminded_api"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+type UserDataSource struct{}
+
+func TestAccUserDataSourceById(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	d := UserDataSource{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   d.user_by_id(connection, data.RandomString),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dataminded_user.test", "name", fmt.Sprintf("test_%s", data.RandomString)),
+					resource.TestCheckResourceAttr("data.dataminded_user.test", "chapters.#", "2"),
+					// Chapters are sorted by name
+					resource.TestCheckResourceAttrPair("data.dataminded_user.test", "chapters.0.id", "dataminded_chapter.data", "id"),
+					resource.TestCheckResourceAttr("data.dataminded_user.test", "chapters.0.name", fmt.Sprintf("data_%s", data.RandomString)),
+					resource.TestCheckResourceAttr("data.dataminded_user.test", "chapters.0.role", "Contributor"),
+					resource.TestCheckResourceAttrPair("data.dataminded_user.test", "chapters.1.id", "dataminded_chapter.platform", "id"),
+					resource.TestCheckResourceAttr("data.dataminded_user.test", "chapters.1.role", "Lead"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUserDataSourceByName(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	d := UserDataSource{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   d.user_by_name(connection, data.RandomString),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.dataminded_user.test", "id", "dataminded_user.test", "id"),
+					resource.TestCheckResourceAttr("data.dataminded_user.test", "chapters.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUserDataSourceInvalidLookup(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	d := UserDataSource{}
+
+	tests := []struct {
+		name    string
+		lookup  string
+		message string
+	}{
+		{
+			name:    "neither",
+			lookup:  ``,
+			message: `(?s)Missing Attribute Configuration.*\[id,name\]`,
+		},
+		{
+			name:    "both",
+			lookup:  `id = 1` + "\n" + `name = "Jelle"`,
+			message: `(?s)Invalid Attribute Combination.*\[id,name\]`,
+		},
+		{
+			name:    "unknown id",
+			lookup:  fmt.Sprintf(`id = %d`, data.RandomInteger),
+			message: `Reading user failed`,
+		},
+		{
+			name:    "unknown name",
+			lookup:  fmt.Sprintf(`name = "missing_%s"`, data.RandomString),
+			message: `no user is named\s+"missing_`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				Steps: []resource.TestStep{
+					{
+						Config:                   d.user_lookup(connection, test.lookup),
+						ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+						ExpectError:              regexp.MustCompile(test.message),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccUserDataSourceAmbiguousName(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	d := UserDataSource{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   d.user_ambiguous(connection, data.RandomString),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ExpectError:              regexp.MustCompile(`2 users are named\s+"test_`),
+			},
+		},
+	})
+}
+
+func (d UserDataSource) user_by_id(connection dataminded_api.Connection, name string) string {
+	template := d.template(connection)
+
+	return fmt.Sprintf(
+		`
+		%[1]s
+
+		resource "dataminded_user" "test" {
+			name = "test_%[2]s"
+		}
+
+		resource "dataminded_chapter" "platform" {
+			name = "platform_%[2]s"
+		}
+
+		resource "dataminded_chapter" "data" {
+			name = "data_%[2]s"
+		}
+
+		resource "dataminded_chapter_member" "platform" {
+			chapter = dataminded_chapter.platform.id
+			member  = dataminded_user.test.id
+			role    = "Lead"
+		}
+
+		resource "dataminded_chapter_member" "data" {
+			chapter = dataminded_chapter.data.id
+			member  = dataminded_user.test.id
+		}
+
+		data "dataminded_user" "test" {
+			id = dataminded_user.test.id
+
+			depends_on = [dataminded_chapter_member.platform, dataminded_chapter_member.data]
+		}
+		`, template, name)
+}
+
+func (d UserDataSource) user_by_name(connection dataminded_api.Connection, name string) string {
+	template := d.template(connection)
+
+	return fmt.Sprintf(
+		`
+		%[1]s
+
+		resource "dataminded_user" "test" {
+			name = "test_%[2]s"
+		}
+
+		data "dataminded_user" "test" {
+			name = dataminded_user.test.name
+		}
+		`, template, name)
+}
+
+func (d UserDataSource) user_ambiguous(connection dataminded_api.Connection, name string) string {
+	template := d.template(connection)
+
+	return fmt.Sprintf(
+		`
+		%[1]s
+
+		resource "dataminded_user" "test" {
+			name = "test_%[2]s"
+		}
+
+		resource "dataminded_user" "namesake" {
+			name = "test_%[2]s"
+		}
+
+		data "dataminded_user" "test" {
+			name = "test_%[2]s"
+
+			depends_on = [dataminded_user.test, dataminded_user.namesake]
+		}
+		`, template, name)
+}
+
+func (d UserDataSource) user_lookup(connection dataminded_api.Connection, lookup string) string {
+	template := d.template(connection)
+
+	return fmt.Sprintf(
+		`
+		%[1]s
+
+		data "dataminded_user" "test" {
+			%[2]s
+		}
+		`, template, lookup)
+}
+
+func (d UserDataSource) template(connection dataminded_api.Connection) string {
+	return fmt.Sprintf(`
+		provider "dataminded" {
+			endpoint = "%s"
+		}
+	`, connection.Endpoint)
+}
@@ -2,11 +2,15 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"terraform-provider-dataminded/internal/dataminded_api"
 	"terraform-provider-dataminded/internal/logging"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -14,8 +18,9 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &UserResource{}
-	_ resource.ResourceWithConfigure = &UserResource{}
+	_ resource.Resource                = &UserResource{}
+	_ resource.ResourceWithConfigure   = &UserResource{}
+	_ resource.ResourceWithImportState = &UserResource{}
 )
 
 func NewUserResource() resource.Resource {
@@ -23,7 +28,7 @@ func NewUserResource() resource.Resource {
 }
 
 type UserResource struct {
-	Connection dataminded_api.Connection
+	Client *dataminded_api.Client
 }
 
 func (r *UserResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -58,7 +63,7 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	name := plan.Name.ValueString()
 
-	user, err := dataminded_api.CreateUser(r.Connection, name)
+	user, err := r.Client.CreateUser(ctx, name)
 
 	if err != nil {
 		logging.AddError(ctx, "User creation failed", err)
@@ -87,15 +92,15 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	id := state.Id.ValueInt64()
-	user, err := dataminded_api.ReadUser(r.Connection, int(id))
+	user, err := r.Client.ReadUser(ctx, int(id))
 
-	if err != nil {
-		logging.AddError(ctx, "Reading user failed", err)
+	if errors.Is(err, dataminded_api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	if !dataminded_api.UserExists(user) {
-		resp.State.RemoveResource(ctx)
+	if err != nil {
+		logging.AddError(ctx, "Reading user failed", err)
 		return
 	}
 
@@ -127,7 +132,7 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	id := int(state.Id.ValueInt64())
 	newName := plan.Name.ValueString()
 
-	user, err := dataminded_api.UpdateUser(r.Connection, id, newName)
+	user, err := r.Client.UpdateUser(ctx, id, newName)
 
 	if err != nil {
 		logging.AddError(ctx, "Updating user failed", err)
@@ -157,29 +162,61 @@ func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 
 	id := int(state.Id.ValueInt64())
 
-	err := dataminded_api.DeleteUser(r.Connection, id)
+	err := r.Client.DeleteUser(ctx, id)
 
-	if err != nil {
+	// A user that is already gone needs no dropping
+	if err != nil && !errors.Is(err, dataminded_api.ErrNotFound) {
 		logging.AddError(ctx, "Dropping user failed", err)
 	}
 }
 
+// ImportState adopts an existing user by its numeric id or by name:<name>, Read
+// fills in the rest.
+func (r *UserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = logging.WithDiagnostics(ctx, &resp.Diagnostics)
+
+	if name, found := strings.CutPrefix(req.ID, "name:"); found {
+		id, err := r.Client.UserIdByName(ctx, name)
+
+		if err != nil {
+			logging.AddError(ctx, "Importing user by name failed", err)
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), int64(id))...)
+		return
+	}
+
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid import id",
+			fmt.Sprintf("Expected the numeric id of the user or name:<name>, got: %q.", req.ID),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
 func (r *UserResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 
 	if req.ProviderData == nil {
 		return
 	}
 
-	connection, ok := req.ProviderData.(*dataminded_api.Connection)
+	client, ok := req.ProviderData.(*dataminded_api.Client)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Configure Type",
-			fmt.Sprintf("Expected *dataminded_api.Connection got: %T.", req.ProviderData),
+			fmt.Sprintf("Expected *dataminded_api.Client got: %T.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.Connection = *connection
+	r.Client = client
 }
@@ -2,6 +2,8 @@ package user_test
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"testing"
 
 	"terraform-provider-dataminded/internal/acceptance"
@@ -15,8 +17,7 @@ type UserResource struct{}
 func TestAccCreateUser(t *testing.T) {
 	data := acceptance.BuildTestData(t)
 	connection := dataminded_api.Connection{
-		Host: data.Host,
-		Port: data.Port,
+		Endpoint: data.Endpoint,
 	}
 	r := UserResource{}
 
@@ -36,8 +37,7 @@ func TestAccCreateUser(t *testing.T) {
 func TestAccUpdateUser(t *testing.T) {
 	data := acceptance.BuildTestData(t)
 	connection := dataminded_api.Connection{
-		Host: data.Host,
-		Port: data.Port,
+		Endpoint: data.Endpoint,
 	}
 	r := UserResource{}
 
@@ -58,6 +58,128 @@ func TestAccUpdateUser(t *testing.T) {
 	})
 }
 
+func TestAccUserDeletedOutsideTerraform(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	client := dataminded_api.NewClient(connection)
+	r := UserResource{}
+
+	var id int
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   r.user_basic(connection, data.RandomString),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.TestCheckResourceAttrWith("dataminded_user.test", "id", func(value string) (err error) {
+					id, err = strconv.Atoi(value)
+					return err
+				}),
+			},
+			{
+				PreConfig: func() {
+					if err := client.DeleteUser(t.Context(), id); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:                   r.user_basic(connection, data.RandomString),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				PlanOnly:                 true,
+				ExpectNonEmptyPlan:       true,
+			},
+		},
+	})
+}
+
+func TestAccImportUser(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	r := UserResource{}
+
+	acceptance.ImportTest(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: r.user_basic(connection, data.RandomString),
+			},
+			{
+				Config:            r.user_basic(connection, data.RandomString),
+				ResourceName:      "dataminded_user.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:          r.user_basic(connection, data.RandomString),
+				ResourceName:    "dataminded_user.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			{
+				Config:        r.user_basic(connection, data.RandomString),
+				ResourceName:  "dataminded_user.test",
+				ImportState:   true,
+				ImportStateId: "user_{}",
+				ExpectError:   regexp.MustCompile(`Expected the numeric id of the user`),
+			},
+		},
+	})
+}
+
+func TestAccImportUserByName(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	r := UserResource{}
+
+	acceptance.ImportTest(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: r.user_basic(connection, data.RandomString),
+			},
+			{
+				Config:            r.user_basic(connection, data.RandomString),
+				ResourceName:      "dataminded_user.test",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("name:test_%s", data.RandomString),
+				ImportStateVerify: true,
+			},
+			{
+				Config:        r.user_basic(connection, data.RandomString),
+				ResourceName:  "dataminded_user.test",
+				ImportState:   true,
+				ImportStateId: fmt.Sprintf("name:missing_%s", data.RandomString),
+				ExpectError:   regexp.MustCompile(`no user is named\s+"missing_`),
+			},
+		},
+	})
+}
+
+func TestAccImportUserByAmbiguousName(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	r := UserResource{}
+
+	acceptance.ImportTest(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: r.user_twice(connection, data.RandomString),
+			},
+			{
+				Config:        r.user_twice(connection, data.RandomString),
+				ResourceName:  "dataminded_user.test",
+				ImportState:   true,
+				ImportStateId: fmt.Sprintf("name:test_%s", data.RandomString),
+				ExpectError:   regexp.MustCompile(`2 users are named\s+"test_`),
+			},
+		},
+	})
+}
+
 func (r UserResource) user_basic(connection dataminded_api.Connection, name string) string {
 	template := r.template(connection)
 
@@ -71,11 +193,27 @@ func (r UserResource) user_basic(connection dataminded_api.Connection, name stri
 		`, template, name)
 }
 
+func (r UserResource) user_twice(connection dataminded_api.Connection, name string) string {
+	template := r.template(connection)
+
+	return fmt.Sprintf(
+		`
+		%[1]s
+
+		resource "dataminded_user" "test" {
+			name = "test_%[2]s"
+		}
+
+		resource "dataminded_user" "namesake" {
+			name = "test_%[2]s"
+		}
+		`, template, name)
+}
+
 func (r UserResource) template(connection dataminded_api.Connection) string {
 	return fmt.Sprintf(`
 		provider "dataminded" {
-			host = "%s"
-			port = %d
+			endpoint = "%s"
 		}
-	`, connection.Host, connection.Port)
+	`, connection.Endpoint)
 }
@@ -0,0 +1,266 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"terraform-provider-dataminded/internal/dataminded_api"
+	"terraform-provider-dataminded/internal/logging"
+	"terraform-provider-dataminded/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &UsersDataSource{}
+	_ datasource.DataSourceWithConfigure = &UsersDataSource{}
+)
+
+func NewUsersDataSource() datasource.DataSource {
+	return &UsersDataSource{}
+}
+
+type UsersDataSource struct {
+	Client *dataminded_api.Client
+}
+
+func (d *UsersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+func (d *UsersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	user := schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Id of the user in the sqlite database.",
+			},
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the user",
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "List Dataminded users, optionally filtered. A user is listed when it passes every filter that is set.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Regular expression, in Go syntax, that the name of a user must match somewhere.",
+				Validators: []validator.String{
+					validators.Regex(),
+				},
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Prefix the name of a user must start with.",
+			},
+			"ids": schema.SetAttribute{
+				ElementType: types.Int64Type,
+				Optional:    true,
+				Description: "Ids the user must be one of.",
+			},
+			"member_of_chapter": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Id of a chapter the user must be a member of.",
+			},
+			"users": schema.ListNestedAttribute{
+				Computed:     true,
+				Description:  "Users passing the filters, sorted by name and then id.",
+				NestedObject: user,
+			},
+			"by_name": schema.MapNestedAttribute{
+				Computed:     true,
+				Description:  "Users passing the filters, keyed by name. Names shared by several of these users are left out with a warning, use users for them.",
+				NestedObject: user,
+			},
+		},
+	}
+}
+
+func (d *UsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = logging.WithDiagnostics(ctx, &resp.Diagnostics)
+
+	var config UsersDataSourceModel
+
+	// Read input configured in data block
+	resp.Diagnostics.Append(
+		req.Config.Get(ctx, &config)...,
+	)
+
+	if logging.HasError(ctx) {
+		return
+	}
+
+	filters, err := d.filters(ctx, config)
+
+	if err != nil {
+		logging.AddError(ctx, "Reading users failed", err)
+		return
+	}
+
+	users, err := d.Client.ListUsers(ctx)
+
+	if err != nil {
+		logging.AddError(ctx, "Reading users failed", err)
+		return
+	}
+
+	var listed []dataminded_api.User
+	for _, user := range users {
+		if filters.match(user) {
+			listed = append(listed, user)
+		}
+	}
+
+	sort.Slice(listed, func(i, j int) bool {
+		if listed[i].Name != listed[j].Name {
+			return listed[i].Name < listed[j].Name
+		}
+		return listed[i].Id < listed[j].Id
+	})
+
+	config.Users = []UserSummaryModel{}
+	config.ByName = map[string]UserSummaryModel{}
+	var shared []string
+
+	for _, user := range listed {
+		summary := UserSummaryModel{
+			Id:   types.Int64Value(int64(user.Id)),
+			Name: types.StringValue(user.Name),
+		}
+		config.Users = append(config.Users, summary)
+
+		if _, found := config.ByName[user.Name]; found {
+			if len(shared) == 0 || shared[len(shared)-1] != user.Name {
+				shared = append(shared, user.Name)
+			}
+			continue
+		}
+		config.ByName[user.Name] = summary
+	}
+
+	for _, name := range shared {
+		delete(config.ByName, name)
+	}
+
+	if len(shared) > 0 {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("by_name"),
+			"Users sharing a name",
+			fmt.Sprintf("Several users are named %s, so by_name leaves those names out. Use users to reach them.", strings.Join(quoted(shared), ", ")),
+		)
+	}
+
+	diags := resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// userFilters holds the filters of the data block, a nil field matches every user.
+type userFilters struct {
+	nameRegex  *regexp.Regexp
+	namePrefix *string
+	ids        map[int]bool
+	members    map[int]bool
+}
+
+func (d *UsersDataSource) filters(ctx context.Context, config UsersDataSourceModel) (userFilters, error) {
+	var filters userFilters
+
+	if !config.NameRegex.IsNull() {
+		nameRegex, err := regexp.Compile(config.NameRegex.ValueString())
+		if err != nil {
+			return filters, err
+		}
+		filters.nameRegex = nameRegex
+	}
+
+	if !config.NamePrefix.IsNull() {
+		namePrefix := config.NamePrefix.ValueString()
+		filters.namePrefix = &namePrefix
+	}
+
+	if !config.Ids.IsNull() {
+		var ids []int64
+		if diags := config.Ids.ElementsAs(ctx, &ids, false); diags.HasError() {
+			return filters, fmt.Errorf("reading ids: %v", diags)
+		}
+
+		filters.ids = map[int]bool{}
+		for _, id := range ids {
+			filters.ids[int(id)] = true
+		}
+	}
+
+	if !config.MemberOfChapter.IsNull() {
+		members, err := d.Client.ListChapterMembers(ctx, int(config.MemberOfChapter.ValueInt64()))
+		if err != nil {
+			return filters, err
+		}
+
+		filters.members = map[int]bool{}
+		for _, member := range members {
+			filters.members[member.UserId] = true
+		}
+	}
+
+	return filters, nil
+}
+
+func (f userFilters) match(user dataminded_api.User) bool {
+	if f.nameRegex != nil && !f.nameRegex.MatchString(user.Name) {
+		return false
+	}
+
+	if f.namePrefix != nil && !strings.HasPrefix(user.Name, *f.namePrefix) {
+		return false
+	}
+
+	if f.ids != nil && !f.ids[user.Id] {
+		return false
+	}
+
+	if f.members != nil && !f.members[user.Id] {
+		return false
+	}
+
+	return true
+}
+
+func quoted(names []string) []string {
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		result = append(result, fmt.Sprintf("%q", name))
+	}
+
+	return result
+}
+
+func (d *UsersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*dataminded_api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Configure Type",
+			fmt.Sprintf("Expected *dataminded_api.Client got: %T.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.Client = client
+}
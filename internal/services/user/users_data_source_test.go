@@ -0,0 +1,191 @@
+package user_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"terraform-provider-dataminded/internal/acceptance"
+	"terraform-provider-dataminded/internal/dataminded_api"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+type UsersDataSource struct{}
+
+func TestAccUsersDataSourceByPrefix(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	d := UsersDataSource{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   d.users_filtered(connection, data.RandomString, fmt.Sprintf(`name_prefix = "test_%s_"`, data.RandomString)),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dataminded_users.test", "users.#", "3"),
+					// Users are sorted by name, not by creation
+					resource.TestCheckResourceAttrPair("data.dataminded_users.test", "users.0.id", "dataminded_user.alice", "id"),
+					resource.TestCheckResourceAttrPair("data.dataminded_users.test", "users.1.id", "dataminded_user.bob", "id"),
+					resource.TestCheckResourceAttrPair("data.dataminded_users.test", "users.2.id", "dataminded_user.carol", "id"),
+					resource.TestCheckResourceAttr("data.dataminded_users.test", "by_name.%", "3"),
+					resource.TestCheckResourceAttrPair("data.dataminded_users.test", fmt.Sprintf("by_name.test_%s_bob.id", data.RandomString), "dataminded_user.bob", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUsersDataSourceFilters(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	d := UsersDataSource{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: d.users_filtered(connection, data.RandomString, fmt.Sprintf(`
+					name_regex = "^test_%s_(alice|carol)$"
+					ids        = [dataminded_user.alice.id, dataminded_user.bob.id]
+				`, data.RandomString)),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dataminded_users.test", "users.#", "1"),
+					resource.TestCheckResourceAttrPair("data.dataminded_users.test", "users.0.id", "dataminded_user.alice", "id"),
+				),
+			},
+			{
+				Config:                   d.users_filtered(connection, data.RandomString, `member_of_chapter = dataminded_chapter.test.id`),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dataminded_users.test", "users.#", "1"),
+					resource.TestCheckResourceAttrPair("data.dataminded_users.test", "users.0.id", "dataminded_user.bob", "id"),
+				),
+			},
+			{
+				Config:                   d.users_filtered(connection, data.RandomString, `ids = []`),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dataminded_users.test", "users.#", "0"),
+					resource.TestCheckResourceAttr("data.dataminded_users.test", "by_name.%", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUsersDataSourceSharedName(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	d := UsersDataSource{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   d.users_shared_name(connection, data.RandomString),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dataminded_users.test", "users.#", "3"),
+					// The namesakes are listed by id
+					resource.TestCheckResourceAttrPair("data.dataminded_users.test", "users.0.id", "dataminded_user.alice", "id"),
+					resource.TestCheckResourceAttrPair("data.dataminded_users.test", "users.1.id", "dataminded_user.namesake", "id"),
+					resource.TestCheckResourceAttr("data.dataminded_users.test", "by_name.%", "1"),
+					resource.TestCheckResourceAttrPair("data.dataminded_users.test", fmt.Sprintf("by_name.test_%s_bob.id", data.RandomString), "dataminded_user.bob", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUsersDataSourceInvalidRegex(t *testing.T) {
+	data := acceptance.BuildTestData(t)
+	connection := dataminded_api.Connection{
+		Endpoint: data.Endpoint,
+	}
+	d := UsersDataSource{}
+
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:                   d.users_filtered(connection, data.RandomString, `name_regex = "test_("`),
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				ExpectError:              regexp.MustCompile(`Invalid regular expression`),
+			},
+		},
+	})
+}
+
+func (d UsersDataSource) users_filtered(connection dataminded_api.Connection, name string, filters string) string {
+	template := d.template(connection, name)
+
+	return fmt.Sprintf(
+		`
+		%[1]s
+
+		resource "dataminded_chapter" "test" {
+			name = "test_%[2]s"
+		}
+
+		resource "dataminded_chapter_member" "bob" {
+			chapter = dataminded_chapter.test.id
+			member  = dataminded_user.bob.id
+		}
+
+		data "dataminded_users" "test" {
+			%[3]s
+
+			depends_on = [dataminded_user.alice, dataminded_user.bob, dataminded_user.carol, dataminded_chapter_member.bob]
+		}
+		`, template, name, filters)
+}
+
+func (d UsersDataSource) users_shared_name(connection dataminded_api.Connection, name string) string {
+	template := d.template(connection, name)
+
+	return fmt.Sprintf(
+		`
+		%[1]s
+
+		resource "dataminded_user" "namesake" {
+			name = "test_%[2]s_alice"
+
+			depends_on = [dataminded_user.alice]
+		}
+
+		data "dataminded_users" "test" {
+			name_prefix = "test_%[2]s_"
+			ids         = [dataminded_user.alice.id, dataminded_user.namesake.id, dataminded_user.bob.id]
+		}
+		`, template, name)
+}
+
+func (d UsersDataSource) template(connection dataminded_api.Connection, name string) string {
+	return fmt.Sprintf(`
+		provider "dataminded" {
+			endpoint = "%[1]s"
+		}
+
+		resource "dataminded_user" "carol" {
+			name = "test_%[2]s_carol"
+		}
+
+		resource "dataminded_user" "alice" {
+			name = "test_%[2]s_alice"
+		}
+
+		resource "dataminded_user" "bob" {
+			name = "test_%[2]s_bob"
+		}
+
+		resource "dataminded_user" "outsider" {
+			name = "other_%[2]s"
+		}
+	`, connection.Endpoint, name)
+}
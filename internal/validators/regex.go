@@ -0,0 +1,39 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Regex returns a validator that reports a string that is not a valid regular
+// expression at plan time, rather than when the data source is read.
+func Regex() validator.String {
+	return regexValidator{}
+}
+
+type regexValidator struct{}
+
+func (v regexValidator) Description(_ context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+func (v regexValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regexValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid regular expression",
+			fmt.Sprintf("The %s is not a valid regular expression: %s.", req.Path, err),
+		)
+	}
+}
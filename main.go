@@ -19,6 +19,10 @@ import (
 // ensure the documentation is formatted properly.
 //go:generate terraform fmt -recursive ./examples/
 
+// Generate the typed API client from the checked-in OpenAPI document of the API. Refresh the document
+// from a running API with: curl -s http://localhost:3000/api.json | jq . > internal/dataminded_api/openapi/api.json
+//go:generate go run ./internal/openapigen/cmd -spec internal/dataminded_api/openapi/api.json -out internal/dataminded_api/openapi/openapi.gen.go -package openapi
+
 // Run the docs generation tool, check its repository for more information on how it works and how docs
 // can be customized.
 //go:generate go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs generate -provider-name dataminded
@@ -7,8 +7,7 @@ terraform {
 }
 
 provider "dataminded" {
-  host = "http://localhost"
-  port = 3000
+  endpoint = "http://localhost:3000"
 }
 
 
@@ -16,14 +15,6 @@ locals {
   chapter_config = yamldecode(file("${path.module}/chapter_config.yaml"))
   chapters       = keys(local.chapter_config)
   users          = toset(flatten([for users in values(local.chapter_config) : [for user in users : user.name]]))
-  chapter_roles = merge([
-    for chapter, users in local.chapter_config : {
-      for user in users : "${chapter}-${user.name}" => {
-        user    = user.name
-        role    = try(user.role, "Contributor")
-        chapter = chapter
-      }
-  }]...) // Bleh, this is ugly... Let's use a provider-defined function instead!
 }
 
 
@@ -37,12 +28,11 @@ resource "dataminded_chapter" "chapter" {
   name     = each.key
 }
 
-// Exercise 3 replaces `local.chapter_roles` here with a call to your
-// provider-defined function. See internal/services/functions/README.md.
 resource "dataminded_chapter_member" "chapter_member" {
-  for_each = local.chapter_roles
+  for_each = provider::dataminded::chapter_config_parser(file("${path.module}/chapter_config.yaml"))
   chapter  = dataminded_chapter.chapter[each.value.chapter].id
-  member   = dataminded_user.user[each.value.user].id
+  member   = dataminded_user.user[each.value.name].id
+  role     = each.value.role
 }
 
 